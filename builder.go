@@ -17,6 +17,9 @@ package validation
 import (
 	"errors"
 	"fmt"
+	"go/token"
+	"sort"
+	"strings"
 	"sync"
 	"sync/atomic"
 
@@ -55,67 +58,378 @@ func Validate(v any, rule string) error {
 	return DefaultBuilder.Validate(v, rule)
 }
 
+// TryValidate is equal to DefaultBuilder.TryValidate(v, rule).
+func TryValidate(v any, rule string) error {
+	return DefaultBuilder.TryValidate(v, rule)
+}
+
+// Compile is equal to DefaultBuilder.Compile(rules).
+func Compile(rules map[string]string) (map[string]validator.Validator, error) {
+	return DefaultBuilder.Compile(rules)
+}
+
+// ValidateAll is equal to DefaultBuilder.ValidateAll(v, rule, maxErrors).
+func ValidateAll(v any, rule string, maxErrors int) error {
+	return DefaultBuilder.ValidateAll(v, rule, maxErrors)
+}
+
+// Explain is equal to DefaultBuilder.Explain(v, rule).
+func Explain(v any, rule string) (*validator.Report, error) {
+	return DefaultBuilder.Explain(v, rule)
+}
+
+// ExplainContext is equal to DefaultBuilder.ExplainContext(c, v, rule).
+func ExplainContext(c *validator.Context, v any, rule string) (*validator.Report, error) {
+	return DefaultBuilder.ExplainContext(c, v, rule)
+}
+
 // Builder is used to build the validator based on the rule.
+//
+// Besides the registered functions and symbols, the builder supports
+// the built-in identifiers as the operand of the comparison operators
+// "==", "!=", "<", "<=", ">" and ">=":
+//
+//	len:   the length of the validated value, such as "len >= 3 && len < 64"
+//	value: the validated value itself, such as "value > 0", "value != 13"
+//
+// For "==", if both the operands are not the built-in identifiers,
+// it is equal to calling the function, such as "min == 3" => "min(3)".
+//
+// The argument or operand may be a runtime variable like "$name",
+// such as "max($quota)" and "value < $limit", which is resolved
+// when validating the value. See ValidateWithVars.
+//
+// The builder is safe for the concurrent use, including registering
+// and unregistering the functions and symbols while building the rules.
+// And Freeze can be used to make it immutable after initialization.
 type Builder struct {
-	// Symbols is used to define the global symbols,
-	// which is used by the default of GetIdentifier.
+	// version is increased whenever the registry is changed, which must be
+	// the first field to ensure the 64-bit alignment for the atomic access.
+	version uint64
+
+	// Symbols is the global symbols used by the old versions.
+	//
+	// Deprecated: use RegisterSymbol, Symbol and AllSymbols instead.
+	// It is only looked up as the fallback of the symbols registered
+	// by RegisterSymbol. Modifying it is not safe for the concurrent use,
+	// and does not clear the cache of the built validators.
 	Symbols map[string]any
 
+	// Builder is the rule builder used by the old versions.
+	//
+	// Deprecated: the rule is parsed and built by Builder itself, and the
+	// methods of the embedded builder, such as GetFunc, RegisterFunc and
+	// Build, are shadowed by those of Builder. Only its fields GetIdentifier,
+	// EQ, NEQ, LT, GT, LE and GE are used as the hooks to customize
+	// the identifier lookup and the comparison operators if they are set,
+	// and GetProperty is not used. Modifying them is not safe for
+	// the concurrent use, and does not clear the cache of the built validators.
 	*predicate.Builder
-	validators atomic.Value
-	vcacheLock sync.Mutex
-	vcacheMap  map[string]validator.Validator
+
+	parent *Builder
+
+	// lock protects the fields below it.
+	//
+	// The registration of the named rules is serialized by ruleLock,
+	// so rules is modified by holding both ruleLock and lock,
+	// and is read by holding either of them.
+	lock       sync.RWMutex
+	frozen     bool
+	optimizing bool
+	limits     Limits
+	funcs      map[string]predicate.BuilderFunction
+	infos      map[string]FuncInfo
+	symbols    map[string]any
+	rules      map[string]*namedRule
+
+	ruleLock sync.Mutex
+	cache    *validatorCache
 }
 
+// ErrFrozen is returned or panicked when modifying the frozen builder.
+var ErrFrozen = errors.New("the builder has been frozen")
+
 // NewBuilder returns a new validation rule builder.
 func NewBuilder() *Builder {
-	builder := &Builder{
-		vcacheMap: make(map[string]validator.Validator),
-		Symbols:   make(map[string]any),
+	b := &Builder{
+		Symbols: make(map[string]any),
+		Builder: predicate.NewBuilder(),
+		funcs:   make(map[string]predicate.BuilderFunction),
+		infos:   make(map[string]FuncInfo),
+		symbols: make(map[string]any),
+		rules:   make(map[string]*namedRule),
+		cache:   newValidatorCache(DefaultCacheSize),
 	}
 
-	builder.Builder = predicate.NewBuilder()
-	builder.Builder.GetIdentifier = builder.getIdentifier
-	builder.Builder.EQ = builder.eq
+	b.Builder.GetIdentifier = b.getIdentifier
+	b.Builder.EQ = b.eq
+	return b
+}
 
-	builder.updateValidators()
-	return builder
+// identifier looks up the identifier by the deprecated hook GetIdentifier
+// if it is set, or getIdentifier.
+func (b *Builder) identifier(selector []string) (any, error) {
+	if b.Builder != nil && b.Builder.GetIdentifier != nil {
+		return b.Builder.GetIdentifier(selector)
+	}
+	return b.getIdentifier(selector)
+}
+
+// binaryFunc returns the builder function of the comparison operator op,
+// which is the deprecated hook of the operator if it is set.
+func (b *Builder) binaryFunc(op token.Token) predicate.BinaryBuilderFunc {
+	if pb := b.Builder; pb != nil {
+		var f predicate.BinaryBuilderFunc
+		switch op {
+		case token.EQL:
+			f = pb.EQ
+		case token.NEQ:
+			f = pb.NEQ
+		case token.LSS:
+			f = pb.LT
+		case token.LEQ:
+			f = pb.LE
+		case token.GTR:
+			f = pb.GT
+		case token.GEQ:
+			f = pb.GE
+		}
+
+		if f != nil {
+			return f
+		}
+	}
+
+	switch op {
+	case token.EQL:
+		return b.eq
+	case token.NEQ, token.LSS, token.LEQ, token.GTR, token.GEQ:
+		return compare(op.String())
+	default:
+		return nil
+	}
 }
 
 func (b *Builder) getIdentifier(selector []string) (any, error) {
 	// Support the format "zero" instead of "zero()"
+	name := strings.Join(selector, ".")
 
-	// First, lookup the function table.
-	if f := b.GetFunc(selector[0]); f != nil {
-		return f, nil
+	// First, lookup the function and symbol tables of the builder and parents.
+	if v, ok := b.lookupIdentifier(name); ok {
+		return v, nil
 	}
 
-	// Second, lookup the symbol table.
-	if v, ok := b.Symbols[selector[0]]; ok {
+	// Second, lookup the built-in operands, such as "len" and "value".
+	if v, ok := operands[name]; ok {
 		return v, nil
 	}
 
 	// We find no the identifier.
-	return nil, fmt.Errorf("%s is not defined", selector[0])
+	return nil, fmt.Errorf("%s is not defined", name)
 }
 
-func (b *Builder) eq(ctx predicate.BuilderContext, left, right any) error {
-	// Support the format "min == 123" or "123 == min"
-	if f, ok := left.(predicate.BuilderFunction); ok {
-		return f(ctx, right)
+func (b *Builder) lookupIdentifier(name string) (value any, ok bool) {
+	for ; b != nil; b = b.parent {
+		b.lock.RLock()
+		if value, ok = b.funcs[name]; !ok {
+			value, ok = b.symbol(name)
+		}
+		b.lock.RUnlock()
+
+		if ok {
+			return
+		}
 	}
-	if f, ok := right.(predicate.BuilderFunction); ok {
-		return f(ctx, left)
+	return
+}
+
+// changed increases the version of the builder and clears the cache.
+func (b *Builder) changed() {
+	atomic.AddUint64(&b.version, 1)
+	b.cache.Invalidate()
+}
+
+// getVersion returns the sum of the versions of the builder and its parents,
+// which is changed whenever any of them is changed.
+func (b *Builder) getVersion() (version uint64) {
+	for ; b != nil; b = b.parent {
+		version += atomic.LoadUint64(&b.version)
 	}
-	return fmt.Errorf("left or right is not BuilderFunction: %T, %T", left, right)
+	return
 }
 
-// Validators returns the names of all the validators.
+// Extend returns a new child builder, which has its own functions, symbols,
+// named rules and validator cache, but falls back to the builder as the parent
+// when looking up the function or symbol not registered in the child.
+// For example,
+//
+//	tenant := DefaultBuilder.Extend()
+//	tenant.RegisterSymbol("maxlen", 64)
+//	tenant.RegisterValidatorOneof("region", "east", "west")
+//	tenant.Validate("east", "region")
+//
+// The registrations of the child builder override those of the parent,
+// and do not affect the parent. But the later registrations of the parent
+// are visible to the child, which clears the cache of the child as well.
+//
+// The child inherits the cache size, the optimization switch and the limits of the parent
+// when created, which may be changed independently later.
+//
+// Notice: the named rules of the child referring to those of the parent
+// are not rebuilt when the parent re-registers them.
+func (b *Builder) Extend() *Builder {
+	child := NewBuilder()
+	child.parent = b
+	child.SetCacheSize(b.CacheStats().MaxSize)
+	child.optimizing = b.Optimization()
+	child.limits = b.Limits()
+	return child
+}
+
+// Parent returns the parent builder, which is nil if the builder
+// is not created by Extend.
+func (b *Builder) Parent() *Builder { return b.parent }
+
+// Freeze makes the builder immutable, that's, registering or unregistering
+// the functions, symbols and rules will panic with ErrFrozen, or return it
+// for the methods returning an error, such as RegisterRule.
+//
+// It is used to ensure that the builder is not modified after initialization.
+func (b *Builder) Freeze() {
+	b.lock.Lock()
+	b.frozen = true
+	b.lock.Unlock()
+}
+
+// Frozen reports whether the builder has been frozen.
+func (b *Builder) Frozen() bool {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+	return b.frozen
+}
+
+// ValidatorNames returns the names of all the validators,
+// including those of the parents.
+//
+// For the named rule registered by RegisterRule, the name is formatted
+// as "name => rule", such as "username => min(3) && max(32)".
 func (b *Builder) ValidatorNames() []string {
-	return b.Builder.GetAllFuncNames()
+	var names []string
+	b.walkFuncs(func(name string, r *namedRule, _ FuncInfo) {
+		if r != nil {
+			names = append(names, fmt.Sprintf("%s => %s", name, r.rule))
+		} else {
+			names = append(names, name)
+		}
+	})
+	return names
+}
+
+// GetAllFuncNames returns the names of all the builder functions,
+// including those of the parents.
+func (b *Builder) GetAllFuncNames() []string {
+	var names []string
+	b.walkFuncs(func(name string, _ *namedRule, _ FuncInfo) { names = append(names, name) })
+	return names
+}
+
+// walkFuncs walks all the functions of the builder and its parents,
+// and the function overridden by the child is ignored. If the function
+// is a named rule, r is not nil.
+func (b *Builder) walkFuncs(f func(name string, r *namedRule, info FuncInfo)) {
+	seen := make(map[string]struct{})
+	for ; b != nil; b = b.parent {
+		b.lock.RLock()
+		for name := range b.funcs {
+			if _, ok := seen[name]; !ok {
+				seen[name] = struct{}{}
+				f(name, b.rules[name], b.infos[name])
+			}
+		}
+		b.lock.RUnlock()
+	}
+}
+
+// lookupFuncInfo returns the information of the function by the name,
+// which falls back to the parents if not found.
+func (b *Builder) lookupFuncInfo(name string) (info FuncInfo, ok bool) {
+	for ; b != nil; b = b.parent {
+		b.lock.RLock()
+		if _, ok = b.funcs[name]; ok {
+			info = b.infos[name]
+		}
+		b.lock.RUnlock()
+
+		if ok {
+			return
+		}
+	}
+	return
+}
+
+// GetFunc returns the builder function by the name,
+// which falls back to the parents if not found.
+//
+// Return nil if the function does not exist.
+func (b *Builder) GetFunc(name string) predicate.BuilderFunction {
+	for ; b != nil; b = b.parent {
+		b.lock.RLock()
+		f, ok := b.funcs[name]
+		b.lock.RUnlock()
+
+		if ok {
+			return f
+		}
+	}
+	return nil
+}
+
+// Symbol returns the value of the symbol by the name,
+// which falls back to the parents if not found.
+func (b *Builder) Symbol(name string) (value any, ok bool) {
+	for ; b != nil; b = b.parent {
+		b.lock.RLock()
+		value, ok = b.symbol(name)
+		b.lock.RUnlock()
+
+		if ok {
+			return
+		}
+	}
+	return
+}
+
+// symbol returns the value of the symbol registered in the builder itself,
+// which falls back to the deprecated field Symbols.
+//
+// The caller must hold the lock.
+func (b *Builder) symbol(name string) (value any, ok bool) {
+	if value, ok = b.symbols[name]; !ok {
+		value, ok = b.Symbols[name]
+	}
+	return
+}
+
+// AllSymbols returns all the registered symbols, including those of the parents.
+func (b *Builder) AllSymbols() map[string]any {
+	symbols := make(map[string]any)
+	for ; b != nil; b = b.parent {
+		b.lock.RLock()
+		for _, m := range []map[string]any{b.symbols, b.Symbols} {
+			for name, value := range m {
+				if _, ok := symbols[name]; !ok {
+					symbols[name] = value
+				}
+			}
+		}
+		b.lock.RUnlock()
+	}
+	return symbols
 }
 
 // RegisterSymbol registers the symbol with the name and value.
+//
+// If the symbol has existed, reset it to the new value.
 func (b *Builder) RegisterSymbol(name string, value any) {
 	if name == "" {
 		panic("the symbol name must not be empty")
@@ -123,7 +437,12 @@ func (b *Builder) RegisterSymbol(name string, value any) {
 	if value == nil {
 		panic("the symbol value must not be nil")
 	}
-	b.Symbols[name] = value
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.checkFrozen()
+	b.symbols[name] = value
+	b.changed()
 }
 
 // RegisterSymbolNames registers a set of symbols with the names,
@@ -134,11 +453,74 @@ func (b *Builder) RegisterSymbolNames(names ...string) {
 	}
 }
 
+// UnregisterSymbol unregisters the symbol by the name.
+func (b *Builder) UnregisterSymbol(name string) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.checkFrozen()
+	delete(b.symbols, name)
+	b.changed()
+}
+
 // RegisterFunction registers the builder function.
 //
 // If the function has existed, reset it to the new function.
+//
+// If the function implements the interface FunctionDescriber,
+// its information is registered into the catalog as well.
 func (b *Builder) RegisterFunction(function Function) {
-	b.Builder.RegisterFunc(function.Name(), toBuilderFunction(function))
+	b.registerFunc(function.Name(), toBuilderFunction(function), GetFuncInfo(function))
+}
+
+// RegisterFunc registers the builder function with the name.
+//
+// If the function or named rule has existed, reset it to the new function.
+func (b *Builder) RegisterFunc(name string, f predicate.BuilderFunction) {
+	b.registerFunc(name, f, FuncInfo{Name: name})
+}
+
+func (b *Builder) registerFunc(name string, f predicate.BuilderFunction, info FuncInfo) {
+	if name == "" {
+		panic("the function name must not be empty")
+	}
+	if f == nil {
+		panic("the builder function must not be nil")
+	}
+
+	b.ruleLock.Lock()
+	defer b.ruleLock.Unlock()
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.checkFrozen()
+	b.funcs[name] = f
+	b.infos[name] = info
+	delete(b.rules, name)
+	b.changed()
+}
+
+// UnregisterFunction unregisters the builder function or named rule
+// by the name.
+//
+// Notice: the named rules depending on it are not affected,
+// because they have been built.
+func (b *Builder) UnregisterFunction(name string) {
+	b.ruleLock.Lock()
+	defer b.ruleLock.Unlock()
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.checkFrozen()
+	delete(b.funcs, name)
+	delete(b.infos, name)
+	delete(b.rules, name)
+	b.changed()
+}
+
+func (b *Builder) checkFrozen() {
+	if b.frozen {
+		panic(ErrFrozen)
+	}
 }
 
 // RegisterValidator is the convenient method to convert the validator
@@ -165,65 +547,393 @@ func (b *Builder) RegisterValidatorOneof(name string, values ...string) {
 }
 
 // Build parses and builds the validation rule into the context.
+//
+// If the validator building function panics, such as failing to compile
+// the regular expression, the panic is recovered and returned as the error.
+//
+// The returned error contains *PosError if the position of the error
+// in the rule is known, which can be formatted by FormatError.
 func (b *Builder) Build(c *Context, rule string) error {
-	return b.Builder.Build(c, rule)
+	if err := b.checkRuleLength(rule); err != nil {
+		return err
+	}
+
+	node, err := parseRule(rule)
+	if err != nil {
+		return err
+	}
+	return b.buildRoot(c, rule, node)
+}
+
+// buildRoot builds the syntax tree node parsed from the rule,
+// which is used to locate the position of the error.
+func (b *Builder) buildRoot(c predicate.BuilderContext, rule string, node ruleNode) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = recoverError(r)
+		}
+		err = locatePosError(rule, err)
+	}()
+
+	if bc, ok := c.(*Context); ok && bc.builder == nil {
+		bc.builder = b
+	}
+
+	if err = b.checkLimits(node); err != nil {
+		return
+	}
+	return b.build(c, node)
+}
+
+func (b *Builder) build(c predicate.BuilderContext, node ruleNode) (err error) {
+	defer func() { err = wrapPosError(node, err) }()
+
+	switch n := node.(type) {
+	case *binaryNode:
+		switch n.op {
+		case token.LAND:
+			if err = b.build(c, n.x); err == nil {
+				err = b.build(c, n.y)
+			}
+
+		case token.LOR:
+			oc := c.New()
+			if err = b.buildOr(oc, n); err == nil {
+				c.Or(oc)
+			}
+
+		default:
+			if refersToVars(n.x) || refersToVars(n.y) {
+				c.(*Context).AppendValidators(b.newVarValidator(n))
+			} else {
+				err = b.buildBinary(c, n)
+			}
+		}
+
+	case *notNode:
+		nc := c.New()
+		if err = b.build(nc, n.x); err == nil {
+			c.Not(nc)
+		}
+
+	case *callNode:
+		f := b.GetFunc(n.name)
+		if f == nil {
+			return fmt.Errorf("unsupported function: %s", n.name)
+		}
+
+		for _, arg := range n.args {
+			if refersToVars(arg) {
+				c.(*Context).AppendValidators(b.newVarValidator(n))
+				return
+			}
+		}
+
+		args := make([]any, len(n.args))
+		for i, arg := range n.args {
+			if args[i], err = b.evalArg(arg); err != nil {
+				return
+			}
+		}
+		err = callFunc(f, c, args...)
+
+	case *identNode:
+		var ident any
+		if ident, err = b.identifier(n.selector); err != nil {
+			return
+		}
+
+		if f, ok := ident.(predicate.BuilderFunction); ok {
+			err = callFunc(f, c)
+		} else {
+			err = fmt.Errorf("%s is not a validator", n.Name())
+		}
+
+	case *literalNode:
+		err = fmt.Errorf("unexpected literal %v", n.value)
+
+	case *listNode:
+		err = errors.New("unexpected list")
+
+	case *kwargNode:
+		err = fmt.Errorf("unexpected keyword argument %s", n.name)
+
+	case *varNode:
+		err = fmt.Errorf("unexpected variable $%s", n.name)
+
+	default:
+		panic(fmt.Errorf("unknown rule node type %T", node))
+	}
+
+	return
+}
+
+// callFunc calls the builder function, which recovers the panic as the error,
+// such as failing to compile the regular expression.
+func callFunc(f predicate.BuilderFunction, c predicate.BuilderContext, args ...any) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = recoverError(r)
+		}
+	}()
+	return f(c, args...)
+}
+
+func (b *Builder) buildOr(c predicate.BuilderContext, node ruleNode) (err error) {
+	if n, ok := node.(*binaryNode); ok && n.op == token.LOR {
+		if err = b.buildOr(c, n.x); err == nil {
+			err = b.buildOr(c, n.y)
+		}
+		return
+	}
+
+	nc := c.New()
+	if err = b.build(nc, node); err == nil {
+		c.And(nc)
+	}
+	return
+}
+
+func (b *Builder) buildBinary(c predicate.BuilderContext, n *binaryNode) (err error) {
+	f := b.binaryFunc(n.op)
+	if f == nil {
+		return fmt.Errorf("unsupported binary operator '%s'", n.op)
+	}
+
+	left, err := b.evalOperand(n.x)
+	if err != nil {
+		return
+	}
+
+	right, err := b.evalOperand(n.y)
+	if err != nil {
+		return
+	}
+
+	return f(c, left, right)
+}
+
+// evalArg evaluates the argument of the function call.
+//
+// For the literal, return its value. For the list, return []any.
+// For the keyword argument, return KeywordArg. For the identifier,
+// return the symbol value, or predicate.ContextBuilder if it is a function.
+// For others, return predicate.ContextBuilder.
+func (b *Builder) evalArg(node ruleNode) (value any, err error) {
+	defer func() { err = wrapPosError(node, err) }()
+
+	switch n := node.(type) {
+	case *literalNode:
+		return n.value, nil
+
+	case *listNode:
+		list := make([]any, len(n.elems))
+		for i, elem := range n.elems {
+			v, err := b.evalArg(elem)
+			if err != nil {
+				return nil, err
+			}
+			list[i] = v
+		}
+		return list, nil
+
+	case *kwargNode:
+		v, err := b.evalArg(n.value)
+		if err != nil {
+			return nil, err
+		}
+		return KeywordArg{Name: n.name, Value: v}, nil
+
+	case *identNode:
+		ident, err := b.identifier(n.selector)
+		if err != nil {
+			return nil, err
+		}
+
+		if _, ok := ident.(predicate.BuilderFunction); ok {
+			return ruleBuilder{builder: b, node: n}, nil
+		}
+		return ident, nil
+
+	default:
+		return ruleBuilder{builder: b, node: n}, nil
+	}
+}
+
+// evalOperand evaluates the operand of the binary operator,
+// which is the same as evalArg, but returns predicate.BuilderFunction
+// instead of predicate.ContextBuilder for the function identifier.
+func (b *Builder) evalOperand(node ruleNode) (any, error) {
+	if n, ok := node.(*identNode); ok {
+		return b.identifier(n.selector)
+	}
+	return b.evalArg(node)
+}
+
+// ruleBuilder is used to build the sub-rule as the argument of the function.
+type ruleBuilder struct {
+	builder *Builder
+	node    ruleNode
+}
+
+// Build implements the interface predicate.ContextBuilder.
+func (b ruleBuilder) Build(c predicate.BuilderContext) error {
+	return b.builder.build(c, b.node)
 }
 
 // BuildValidator builds a validator from the validation rule.
 //
-// If the rule has been built, returns it from the caches.
+// If the rule has been built, returns it from the cache. See SetCacheSize.
 func (b *Builder) BuildValidator(rule string) (validator.Validator, error) {
 	if rule == "" {
 		return nil, errors.New("the validation rule must not be empty")
 	}
 
-	if validator, ok := b.loadValidator(rule); ok {
-		return validator, nil
-	}
-
-	b.vcacheLock.Lock()
-	defer b.vcacheLock.Unlock()
-
-	if validator, ok := b.loadValidator(rule); ok {
+	b.cache.Sync(b.getVersion())
+	validator, gen, ok := b.cache.Get(rule)
+	if ok {
 		return validator, nil
 	}
 
+	// Build the rule without the lock, so the different rules can be built
+	// concurrently. And the validator built before the cache is invalidated
+	// will be discarded.
 	c := NewContext()
 	if err := b.Build(c, rule); err != nil {
 		return nil, err
 	}
-
-	validator := c.Validator()
-	b.vcacheMap[rule] = validator
-	b.updateValidators()
-
-	return validator, nil
-}
-
-func (b *Builder) loadValidator(rule string) (v validator.Validator, ok bool) {
-	v, ok = b.validators.Load().(map[string]validator.Validator)[rule]
-	return
+	return b.cache.Add(gen, rule, b.validator(c)), nil
 }
 
-func (b *Builder) updateValidators() {
-	validators := make(map[string]validator.Validator, len(b.vcacheMap))
-	for rule, validator := range b.vcacheMap {
-		validators[rule] = validator
+// validator returns the validator built into the context,
+// which is optimized if the optimization is enabled.
+func (b *Builder) validator(c *Context) validator.Validator {
+	if b.Optimization() {
+		return b.Optimize(c.Validator())
 	}
-	b.validators.Store(validators)
+	return c.Validator()
 }
 
 // Validate validates whether the value v is valid by the rule.
 //
+// If the value is invalid, the returned error is *validator.ValidationError,
+// the path of which is empty if the value is not a container.
+//
 // If failing to build the rule to the validator, panic with the error.
+// So use TryValidate instead for the untrusted rule.
 func (b *Builder) Validate(v any, rule string) (err error) {
 	if rule == "" {
 		return nil
 	}
 
-	validator, err := b.BuildValidator(rule)
+	_validator, err := b.BuildValidator(rule)
 	if err != nil {
 		panic(err)
 	}
-	return validator.Validate(v)
+	return validate(nil, _validator, v)
+}
+
+// TryValidate is the same as Validate, but returns a *RuleError instead of
+// panicking if failing to build the rule to the validator, which can be
+// distinguished from the validation failures by errors.Is(err, ErrInvalidRule).
+func (b *Builder) TryValidate(v any, rule string) (err error) {
+	if rule == "" {
+		return nil
+	}
+
+	_validator, err := b.BuildValidator(rule)
+	if err != nil {
+		return NewRuleError("", rule, err)
+	}
+	return validate(nil, _validator, v)
+}
+
+// validate validates the value v by the validator with the context c,
+// and wraps the returned error as *validator.ValidationError with the empty
+// path if it is not, so it can always be extracted by errors.As.
+//
+// But *RuleError is returned as it is, such as failing to build the rule
+// referring to the runtime variables.
+func validate(c *validator.Context, v validator.Validator, value any) error {
+	err := validator.ValidateContext(c, v, value)
+	if _, ok := err.(*RuleError); ok {
+		return err
+	}
+	return validator.WrapError(err, "", v.String(), value)
+}
+
+// Compile builds a set of the validation rules to the validators at once,
+// the key of which is the name of the rule, such as the struct field name.
+//
+// If some rules are invalid, the returned error is validator.Errors,
+// which contains a *RuleError for every invalid rule in the order of the name.
+// So it can be used to check all the rules at startup.
+func (b *Builder) Compile(rules map[string]string) (map[string]validator.Validator, error) {
+	names := make([]string, 0, len(rules))
+	for name := range rules {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var errs validator.Errors
+	validators := make(map[string]validator.Validator, len(rules))
+	for _, name := range names {
+		v, err := b.BuildValidator(rules[name])
+		if err != nil {
+			errs = append(errs, NewRuleError(name, rules[name], err))
+		} else {
+			validators[name] = v
+		}
+	}
+
+	if len(errs) > 0 {
+		return nil, errs
+	}
+	return validators, nil
+}
+
+// ValidateAll is the same as Validate, but goes on validating to collect
+// all the errors instead of returning the first one, such as the errors
+// of all the conjuncts of AND and all the elements of array or map.
+//
+// If maxErrors is greater than 0, collect at most maxErrors errors.
+//
+// If the value is invalid, the returned error is validator.Errors.
+// If failing to build the rule to the validator, it is a *RuleError like TryValidate.
+func (b *Builder) ValidateAll(v any, rule string, maxErrors int) (err error) {
+	if rule == "" {
+		return nil
+	}
+
+	_validator, err := b.BuildValidator(rule)
+	if err != nil {
+		return NewRuleError("", rule, err)
+	}
+	err = validator.ValidateAll(_validator, v, maxErrors)
+	return validator.WrapError(err, "", _validator.String(), v)
+}
+
+// Explain builds the rule and validates the value v to report the evaluation
+// of every node of the validator tree, which is used to debug the rule.
+// See validator.Explain.
+//
+// If failing to build the rule, the returned error is *RuleError.
+func (b *Builder) Explain(v any, rule string) (*validator.Report, error) {
+	return b.ExplainContext(nil, v, rule)
+}
+
+// ExplainContext is the same as Explain, but validates the value v with
+// the context c, such as the parent struct for the cross-field validators
+// like eqfield and required_if, and the runtime variables. For example,
+//
+//	c := &validator.Context{Parent: user, Vars: validator.VarMap{"quota": 100}}
+//	report, err := b.ExplainContext(c, user.Password2, `eqfield("Password")`)
+//
+// See validator.ExplainContext.
+func (b *Builder) ExplainContext(c *validator.Context, v any, rule string) (*validator.Report, error) {
+	_validator, err := b.BuildValidator(rule)
+	if err != nil {
+		return nil, NewRuleError("", rule, err)
+	}
+	return validator.ExplainContext(c, _validator, v), nil
 }
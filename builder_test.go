@@ -15,12 +15,15 @@
 package validation
 
 import (
+	"errors"
 	"fmt"
 	"reflect"
 	"strings"
+	"sync"
 	"testing"
 
 	"github.com/xgfone/go-validation/validator"
+	"github.com/xgfone/predicate"
 )
 
 func TestValidatorNames(t *testing.T) {
@@ -257,17 +260,367 @@ func ExampleBuilder() {
 	// <nil>
 	// <nil>
 	// <nil>
-	// 0th element is invalid: the integer is less than 1
-	// 2th element is invalid: the string length is less than 1
+	// [0]: the integer is less than 1
+	// [2]: the string length is less than 1
 	//
 	// --- Map ---
 	// <nil>
-	// map key 'abcd' is invalid: the string length is greater than 3
+	// [abcd]: the string length is greater than 3
 	// mapv(min(10) && max(100)) <nil>
 	// <nil>
-	// map value '123' is invalid: the integer is greater than 100
+	// [abcd]: the integer is greater than 100
 	//
 	// --- Others ---
 	// <nil>
 	// the string 'x' is not one of [a b c]
 }
+
+func TestValidateAll(t *testing.T) {
+	type Item struct {
+		Name string `validate:"min(3) && max(8)"`
+		Tags []int  `validate:"array(min(1) && max(9))"`
+	}
+	type Order struct {
+		Items []Item `validate:"max(2) && array(structure)"`
+	}
+
+	order := Order{Items: []Item{{Name: "abc"}, {Name: "a", Tags: []int{0, 5, 10}}, {Name: ""}}}
+	if err := Validate(order, "structure"); err == nil {
+		t.Errorf("expect an error, but got nil")
+	} else if s := err.Error(); s != "Items: the length is greater than 2" {
+		t.Errorf("unexpected error: %s", s)
+	}
+
+	expects := []string{
+		"Items: the length is greater than 2",
+		"Items[1].Name: the string length is less than 3",
+		"Items[1].Tags[0]: the integer is less than 1",
+		"Items[1].Tags[2]: the integer is greater than 9",
+		"Items[2].Name: the string length is less than 3",
+	}
+
+	err := ValidateAll(order, "structure", 0)
+	errs, ok := err.(validator.Errors)
+	if !ok {
+		t.Fatalf("expect validator.Errors, but got %T", err)
+	}
+	if len(errs) != len(expects) {
+		t.Fatalf("expect %d errors, but got %d: %v", len(expects), len(errs), errs)
+	}
+	for i, err := range errs {
+		if s := err.Error(); s != expects[i] {
+			t.Errorf("%d: expect error '%s', but got '%s'", i, expects[i], s)
+		}
+	}
+
+	err = ValidateAll(order, "structure", 3)
+	if errs, ok := err.(validator.Errors); !ok {
+		t.Errorf("expect validator.Errors, but got %T", err)
+	} else if len(errs) != 3 {
+		t.Errorf("expect %d errors, but got %d: %v", 3, len(errs), errs)
+	}
+
+	var re *RuleError
+	if err = ValidateAll(order, "min(1", 0); !errors.As(err, &re) {
+		t.Errorf("expect a RuleError, but got %T: %v", err, err)
+	} else if re.Rule != "min(1" {
+		t.Errorf("expect the rule '%s', but got '%s'", "min(1", re.Rule)
+	}
+}
+
+func TestRuleComparison(t *testing.T) {
+	tests := []struct {
+		rule   string
+		expect string
+	}{
+		{"len >= 3 && len < 64", "(len >= 3 && len < 64)"},
+		{"value > 0", "value > 0"},
+		{"value != 13", "value != 13"},
+		{`value == "abc"`, `value == "abc"`},
+		{"3 <= len", "len >= 3"},
+		{"zero || len == 6", "(zero || len == 6)"},
+	}
+	for _, test := range tests {
+		if v, err := DefaultBuilder.BuildValidator(test.rule); err != nil {
+			t.Errorf("%q: unexpected error: %v", test.rule, err)
+		} else if s := v.String(); s != test.expect {
+			t.Errorf("%q: expect '%s', but got '%s'", test.rule, test.expect, s)
+		}
+	}
+
+	for _, rule := range []string{"len > 1.5", "len < 1e21", "len == value", "value > min(1)", "min(1) < 3"} {
+		if _, err := DefaultBuilder.BuildValidator(rule); err == nil {
+			t.Errorf("%q: expect an error, but got nil", rule)
+		}
+	}
+
+	if err := Validate("abc", "len >= 3 && len < 64"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := Validate("ab", "len >= 3 && len < 64"); err == nil {
+		t.Errorf("expect an error, but got nil")
+	}
+	if err := Validate(12, "value > 0 && value != 13"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := Validate(13, "value > 0 && value != 13"); err == nil {
+		t.Errorf("expect an error, but got nil")
+	}
+}
+
+func TestBuilderRegistry(t *testing.T) {
+	b := NewBuilder()
+	RegisterDefaultsForBuilder(b)
+
+	b.RegisterSymbol("minlen", 3)
+	if err := b.TryValidate("ab", "min(minlen)"); err == nil {
+		t.Errorf("expect an error, but got nil")
+	}
+	if v, ok := b.Symbol("minlen"); !ok || v != 3 {
+		t.Errorf("expect symbol value %d, but got %v", 3, v)
+	}
+
+	b.UnregisterSymbol("minlen")
+	if _, ok := b.AllSymbols()["minlen"]; ok {
+		t.Errorf("unexpect the symbol 'minlen'")
+	}
+	if err := b.TryValidate("ab", "min(minlen)"); !errors.Is(err, ErrInvalidRule) {
+		t.Errorf("expect the error ErrInvalidRule, but got %v", err)
+	}
+
+	// The deprecated field is still looked up for the compatibility.
+	b.Symbols["maxlen"] = 1
+	if err := b.TryValidate("ab", "max(maxlen)"); err == nil || errors.Is(err, ErrInvalidRule) {
+		t.Errorf("expect a validation error, but got %v", err)
+	}
+	if v, ok := b.Symbol("maxlen"); !ok || v != 1 {
+		t.Errorf("expect symbol value %d, but got %v", 1, v)
+	}
+
+	b.UnregisterFunction("min")
+	if b.GetFunc("min") != nil {
+		t.Errorf("unexpect the function 'min'")
+	}
+	if err := b.TryValidate("ab", "min(1)"); !errors.Is(err, ErrInvalidRule) {
+		t.Errorf("expect the error ErrInvalidRule, but got %v", err)
+	}
+
+	b.Freeze()
+	if !b.Frozen() {
+		t.Errorf("expect the builder is frozen")
+	}
+	if err := b.TryValidate("ab", "max(1)"); err == nil {
+		t.Errorf("expect an error, but got nil")
+	}
+	if err := b.RegisterRule("username", "max(32)"); err != ErrFrozen {
+		t.Errorf("expect the error ErrFrozen, but got %v", err)
+	}
+
+	for name, register := range map[string]func(){
+		"RegisterSymbol":     func() { b.RegisterSymbol("a", "b") },
+		"RegisterFunction":   func() { b.RegisterValidatorOneof("a", "b") },
+		"UnregisterSymbol":   func() { b.UnregisterSymbol("timelayout") },
+		"UnregisterFunction": func() { b.UnregisterFunction("max") },
+	} {
+		func() {
+			defer func() {
+				if r := recover(); r != ErrFrozen {
+					t.Errorf("%s: expect the panic ErrFrozen, but got %v", name, r)
+				}
+			}()
+			register()
+		}()
+	}
+}
+
+func TestBuilderConcurrency(t *testing.T) {
+	b := NewBuilder()
+	RegisterDefaultsForBuilder(b)
+	b.SetCacheSize(4)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(3)
+
+		go func(i int) { // Build and validate the rules.
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				_ = b.TryValidate(j, fmt.Sprintf("min(%d) && (zero || isok%d || size)", i, j%4))
+				_ = b.ValidatorNames()
+			}
+		}(i)
+
+		go func(i int) { // Register and unregister the functions and symbols.
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				name := fmt.Sprintf("isok%d", j%4)
+				b.RegisterValidatorFunc(name, func(any) error { return nil })
+				b.RegisterSymbol(fmt.Sprintf("sym%d", i), j)
+				if j%3 == 0 {
+					b.UnregisterFunction(name)
+					b.UnregisterSymbol(fmt.Sprintf("sym%d", i))
+				}
+			}
+		}(i)
+
+		go func(i int) { // Register the named rules.
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				_ = b.RegisterRule("size", fmt.Sprintf("max(%d)", j))
+				_ = b.RegisterRule(fmt.Sprintf("rule%d", i), "size && min(1)")
+				_ = b.Rules()
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	b.Freeze()
+	if err := b.TryValidate(1, "size"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestBuilderExtend(t *testing.T) {
+	parent := NewBuilder()
+	RegisterDefaultsForBuilder(parent)
+	parent.RegisterSymbol("maxlen", 8)
+
+	child := parent.Extend()
+	if child.Parent() != parent {
+		t.Errorf("unexpected parent builder")
+	}
+
+	child.RegisterSymbol("maxlen", 4)
+	child.RegisterValidatorOneof("region", "east", "west")
+
+	if err := child.TryValidate("abcdef", "max(maxlen)"); err == nil {
+		t.Errorf("expect an error, but got nil")
+	}
+	if err := parent.TryValidate("abcdef", "max(maxlen)"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := child.TryValidate("east", "region && min(1)"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := parent.TryValidate("east", "region"); !errors.Is(err, ErrInvalidRule) {
+		t.Errorf("expect the error ErrInvalidRule, but got %v", err)
+	}
+
+	// The later registrations of the parent are visible to the child.
+	if err := child.TryValidate("abc", "isabc"); !errors.Is(err, ErrInvalidRule) {
+		t.Errorf("expect the error ErrInvalidRule, but got %v", err)
+	}
+	parent.RegisterValidatorOneof("isabc", "abc")
+	if err := child.TryValidate("abc", "isabc"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	parent.RegisterValidatorOneof("isabc", "xyz")
+	if err := child.TryValidate("abc", "isabc"); err == nil {
+		t.Errorf("expect an error, but got nil")
+	}
+
+	if v, ok := child.Symbol("datelayout"); !ok || v != "2006-01-02" {
+		t.Errorf("expect the symbol from the parent, but got %v", v)
+	}
+	if v := child.AllSymbols()["maxlen"]; v != 4 {
+		t.Errorf("expect the symbol value %d, but got %v", 4, v)
+	}
+
+	names := child.GetAllFuncNames()
+	if len(names) != len(parent.GetAllFuncNames())+1 {
+		t.Errorf("expect %d functions, but got %d", len(parent.GetAllFuncNames())+1, len(names))
+	}
+
+	if err := child.RegisterRule("min", "max(1)"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	} else if err := child.TryValidate(2, "min"); err == nil {
+		t.Errorf("expect an error, but got nil")
+	} else if err := parent.TryValidate(2, "min(1)"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func ExampleExplain() {
+	report, err := Explain("ab", `min(3) || oneof("a", "b")`)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Print(report)
+
+	_, err = Explain("ab", `min(3`)
+	fmt.Println(err)
+
+	// Output:
+	// FAIL (min(3) || oneof("a", "b")): the string 'ab' is not one of [a b]
+	//   FAIL min(3): the string length is less than 3
+	//   FAIL oneof("a", "b"): the string 'ab' is not one of [a b]
+	// invalid rule 'min(3': 1:6: expected ')', but got 'EOF'
+}
+
+func TestBuilderExplainContext(t *testing.T) {
+	c := &validator.Context{Vars: validator.VarMap{"n": 2}}
+	report, err := DefaultBuilder.ExplainContext(c, 3, "min(1) && max($n)")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	} else if report.Err == nil {
+		t.Errorf("expect an error, but got nil")
+	} else if len(report.Children) != 2 {
+		t.Errorf("expect 2 children, but got %d", len(report.Children))
+	} else if report.Children[0].Err != nil || report.Children[1].Err == nil {
+		t.Errorf("unexpected report: %s", report)
+	}
+
+	type S struct{ Password string }
+	c = &validator.Context{Parent: S{Password: "abc"}}
+	if report, err = ExplainContext(c, "abc", `eqfield("Password")`); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	} else if report.Err != nil {
+		t.Errorf("unexpected error: %v", report.Err)
+	}
+}
+
+func TestBuilderDeprecatedPredicateHooks(t *testing.T) {
+	b := DefaultBuilder.Extend()
+
+	// Resolve the alias "short" as the function "max".
+	getIdentifier := b.GetIdentifier
+	b.GetIdentifier = func(selector []string) (any, error) {
+		if len(selector) == 1 && selector[0] == "short" {
+			return b.GetFunc("max"), nil
+		}
+		return getIdentifier(selector)
+	}
+
+	// Support "max != 3" as "!max(3)".
+	b.NEQ = func(ctx predicate.BuilderContext, left, right any) error {
+		f, ok := left.(predicate.BuilderFunction)
+		if !ok {
+			return fmt.Errorf("expect a function, but got %T", left)
+		}
+
+		nc := ctx.New()
+		if err := f(nc, right); err != nil {
+			return err
+		}
+		ctx.Not(nc)
+		return nil
+	}
+
+	if err := b.TryValidate("abc", "short == 3"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	} else if err := b.TryValidate("abcd", "short == 3"); err == nil {
+		t.Errorf("expect an error, but got nil")
+	}
+
+	if err := b.TryValidate("abc", "max != 2"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	} else if err := b.TryValidate("ab", "max != 2"); err == nil {
+		t.Errorf("expect an error, but got nil")
+	}
+
+	if err := b.TryValidate("abc", "len == 3"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
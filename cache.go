@@ -0,0 +1,225 @@
+// Copyright 2025 xgfone
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"container/list"
+	"sync"
+	"sync/atomic"
+
+	"github.com/xgfone/go-validation/validator"
+)
+
+// DefaultCacheSize is the default maximum number of the built validators
+// cached by the builder.
+const DefaultCacheSize = 1024
+
+// CacheStats is the statistics of the cache of the built validators.
+type CacheStats struct {
+	Size      int    // The number of the cached validators.
+	MaxSize   int    // The maximum number of the cached validators, 0 means no limit.
+	Hits      uint64 // The number of the cache hits.
+	Misses    uint64 // The number of the cache misses.
+	Evictions uint64 // The number of the validators evicted by LRU.
+}
+
+type cacheEntry struct {
+	// stamp is the clock of the cache when the entry is moved to the front,
+	// which is written by holding the lock and read atomically.
+	//
+	// It must be the first field to ensure the 64-bit alignment.
+	stamp uint64
+
+	rule      string
+	validator validator.Validator
+	element   *list.Element
+}
+
+// validatorCache is a LRU cache of the built validators.
+//
+// The generation is increased whenever the cache is invalidated,
+// so the validator built before the invalidation is not cached.
+//
+// The cache hit only holds the read lock, and moves the entry to the front
+// by holding the write lock only if it is not in the front quarter of LRU.
+type validatorCache struct {
+	// The fields accessed atomically must be first
+	// to ensure the 64-bit alignment.
+	hits    uint64
+	misses  uint64
+	clock   uint64 // Increased whenever an entry is moved to the front.
+	version uint64 // The version of the builder registry.
+
+	lock      sync.RWMutex
+	maxSize   int
+	evictions uint64
+	entries   map[string]*cacheEntry
+	lru       *list.List // The front is the most recently used.
+	gen       uint64
+}
+
+func newValidatorCache(maxSize int) *validatorCache {
+	return &validatorCache{
+		maxSize: maxSize,
+		entries: make(map[string]*cacheEntry),
+		lru:     list.New(),
+	}
+}
+
+// Get returns the cached validator by the rule and the current generation.
+func (c *validatorCache) Get(rule string) (v validator.Validator, gen uint64, ok bool) {
+	c.lock.RLock()
+	gen = c.gen
+	e, ok := c.entries[rule]
+	maxSize := c.maxSize
+	c.lock.RUnlock()
+
+	if !ok {
+		atomic.AddUint64(&c.misses, 1)
+		return
+	}
+
+	atomic.AddUint64(&c.hits, 1)
+	if maxSize > 0 && c.needPromotion(e, maxSize) {
+		c.lock.Lock()
+		if c.entries[rule] == e { // Not evicted or invalidated.
+			c.moveToFront(e)
+		}
+		c.lock.Unlock()
+	}
+	return e.validator, gen, true
+}
+
+// needPromotion reports whether the entry is not in the front quarter of LRU,
+// because at most clock-stamp entries have been moved in front of it.
+func (c *validatorCache) needPromotion(e *cacheEntry, maxSize int) bool {
+	threshold := uint64(maxSize / 4)
+	if threshold == 0 {
+		threshold = 1
+	}
+	return atomic.LoadUint64(&c.clock)-atomic.LoadUint64(&e.stamp) >= threshold
+}
+
+// moveToFront moves the entry to the front of LRU by holding the lock.
+func (c *validatorCache) moveToFront(e *cacheEntry) {
+	if e.element == nil {
+		e.element = c.lru.PushFront(e)
+	} else {
+		c.lru.MoveToFront(e.element)
+	}
+	atomic.StoreUint64(&e.stamp, atomic.AddUint64(&c.clock, 1))
+}
+
+// Add caches the validator built at the generation gen, and returns
+// the cached one if another has been cached for the same rule.
+//
+// If the cache has been invalidated since gen, it does not cache it.
+func (c *validatorCache) Add(gen uint64, rule string, v validator.Validator) validator.Validator {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if gen != c.gen {
+		return v
+	}
+
+	if e, ok := c.entries[rule]; ok { // Another has built and cached it.
+		c.moveToFront(e)
+		return e.validator
+	}
+
+	e := &cacheEntry{rule: rule, validator: v}
+	c.entries[rule] = e
+	c.moveToFront(e)
+	c.evict()
+	return v
+}
+
+// Invalidate clears all the cached validators.
+func (c *validatorCache) Invalidate() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.invalidate()
+}
+
+func (c *validatorCache) invalidate() {
+	c.gen++
+	c.lru = list.New()
+	c.entries = make(map[string]*cacheEntry)
+}
+
+// Sync invalidates the cache if the version of the builder registry
+// is newer than that of the cached validators.
+func (c *validatorCache) Sync(version uint64) {
+	if version <= atomic.LoadUint64(&c.version) {
+		return
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if version > c.version {
+		atomic.StoreUint64(&c.version, version)
+		c.invalidate()
+	}
+}
+
+// Resize resets the maximum size of the cache.
+func (c *validatorCache) Resize(maxSize int) {
+	if maxSize < 0 {
+		maxSize = 0
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.maxSize = maxSize
+	c.evict()
+}
+
+// Stats returns the statistics of the cache.
+func (c *validatorCache) Stats() CacheStats {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	return CacheStats{
+		Size:      c.lru.Len(),
+		MaxSize:   c.maxSize,
+		Hits:      atomic.LoadUint64(&c.hits),
+		Misses:    atomic.LoadUint64(&c.misses),
+		Evictions: c.evictions,
+	}
+}
+
+func (c *validatorCache) evict() {
+	for c.maxSize > 0 && c.lru.Len() > c.maxSize {
+		e := c.lru.Remove(c.lru.Back()).(*cacheEntry)
+		delete(c.entries, e.rule)
+		c.evictions++
+	}
+}
+
+// SetCacheSize resets the maximum number of the built validators cached
+// by the builder, and evicts the least recently used ones if exceeding it.
+//
+// If size is equal to or less than 0, there is no limit.
+func (b *Builder) SetCacheSize(size int) { b.cache.Resize(size) }
+
+// CacheStats returns the statistics of the cache of the built validators.
+func (b *Builder) CacheStats() CacheStats { return b.cache.Stats() }
+
+// ClearCache clears all the cached validators built from the rules.
+//
+// The cache is cleared automatically when registering or unregistering
+// the functions, symbols or rules.
+func (b *Builder) ClearCache() { b.cache.Invalidate() }
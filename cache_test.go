@@ -0,0 +1,132 @@
+// Copyright 2025 xgfone
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/xgfone/go-validation/validator"
+)
+
+func TestBuilderCache(t *testing.T) {
+	builder := NewBuilder()
+	RegisterDefaultsForBuilder(builder)
+	builder.SetCacheSize(2)
+
+	for _, rule := range []string{"min(1)", "min(2)", "min(1)", "min(3)"} {
+		if _, err := builder.BuildValidator(rule); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// "min(2)" is evicted as the least recently used.
+	expect := CacheStats{Size: 2, MaxSize: 2, Hits: 1, Misses: 3, Evictions: 1}
+	if stats := builder.CacheStats(); stats != expect {
+		t.Errorf("expect stats %+v, but got %+v", expect, stats)
+	}
+
+	if _, err := builder.BuildValidator("min(1)"); err != nil {
+		t.Fatal(err)
+	} else if stats := builder.CacheStats(); stats.Hits != 2 {
+		t.Errorf("expect %d hits, but got %d", 2, stats.Hits)
+	}
+
+	builder.SetCacheSize(1)
+	if stats := builder.CacheStats(); stats.Size != 1 || stats.Evictions != 2 {
+		t.Errorf("expect size 1 and 2 evictions, but got %+v", stats)
+	}
+
+	// Re-registering the function invalidates the cache.
+	builder.RegisterValidatorFunc("isok", func(any) error { return nil })
+	if err := builder.Validate(0, "isok"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	builder.RegisterValidatorFunc("isok", func(any) error { return errors.New("not ok") })
+	if err := builder.Validate(0, "isok"); err == nil || err.Error() != "not ok" {
+		t.Errorf("expect error 'not ok', but got '%v'", err)
+	}
+
+	builder.ClearCache()
+	if stats := builder.CacheStats(); stats.Size != 0 {
+		t.Errorf("expect an empty cache, but got %d validators", stats.Size)
+	}
+}
+
+func TestBuilderCachePromotion(t *testing.T) {
+	builder := NewBuilder()
+	RegisterDefaultsForBuilder(builder)
+	builder.SetCacheSize(8)
+
+	build := func(rule string) {
+		if _, err := builder.BuildValidator(rule); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for i := 1; i <= 8; i++ {
+		build(fmt.Sprintf("min(%d)", i))
+	}
+
+	// min(8) and min(7) are in the front quarter, so they are not moved,
+	// but min(1) is moved to the front.
+	build("min(8)")
+	build("min(7)")
+	build("min(1)")
+
+	// min(2) is evicted as the least recently used.
+	build("min(9)")
+	build("min(1)")
+	if stats := builder.CacheStats(); stats.Hits != 4 || stats.Evictions != 1 {
+		t.Errorf("expect 4 hits and 1 eviction, but got %+v", stats)
+	}
+	build("min(2)")
+	if stats := builder.CacheStats(); stats.Misses != 10 {
+		t.Errorf("expect 10 misses, but got %+v", stats)
+	}
+}
+
+func TestBuilderCacheConcurrency(t *testing.T) {
+	builder := NewBuilder()
+	RegisterDefaultsForBuilder(builder)
+	builder.SetCacheSize(8)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				rule := fmt.Sprintf("min(%d)", (i+j)%16)
+				v, err := builder.BuildValidator(rule)
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				if v.String() != rule {
+					t.Errorf("expect rule '%s', but got '%s'", rule, v.String())
+				}
+				_ = validator.ValidateAll(v, 100, 0)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if stats := builder.CacheStats(); stats.Size > 8 || stats.Hits+stats.Misses != 800 {
+		t.Errorf("unexpected stats: %+v", stats)
+	}
+}
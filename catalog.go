@@ -0,0 +1,216 @@
+// Copyright 2025 xgfone
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// ParamType is the type of the function parameter.
+type ParamType string
+
+// Predefine some parameter types.
+const (
+	ParamAny       ParamType = "any"
+	ParamInt       ParamType = "int"
+	ParamFloat     ParamType = "float"
+	ParamString    ParamType = "string"
+	ParamValidator ParamType = "validator"
+	ParamBool      ParamType = "bool"
+	ParamDuration  ParamType = "duration"
+	ParamRegexp    ParamType = "regexp" // The string of the regular expression.
+)
+
+// ValueType is the type of the value supported by the validator,
+// which is used by Lint to check whether the rule fits the value.
+type ValueType string
+
+// Predefine some value types.
+const (
+	ValueString ValueType = "string" // A string or fmt.Stringer.
+	ValueNumber ValueType = "number" // An integer or float.
+	ValueBool   ValueType = "bool"
+	ValueList   ValueType = "list" // A slice or array.
+	ValueMap    ValueType = "map"
+	ValueStruct ValueType = "struct"
+	ValueTime   ValueType = "time" // time.Time.
+)
+
+// Param is the parameter of the builder function.
+type Param struct {
+	Name     string    `json:"name,omitempty"`
+	Type     ParamType `json:"type"`
+	Variadic bool      `json:"variadic,omitempty"`
+	Keyword  bool      `json:"keyword,omitempty"` // Passed by name=value.
+}
+
+// String returns the string representation of the parameter,
+// such as "min float", "...string", "loc=string".
+func (p Param) String() string {
+	typ := string(p.Type)
+	if typ == "" {
+		typ = string(ParamAny)
+	}
+	if p.Variadic {
+		typ = "..." + typ
+	}
+
+	switch {
+	case p.Name == "":
+		return typ
+	case p.Keyword:
+		return p.Name + "=" + typ
+	default:
+		return p.Name + " " + typ
+	}
+}
+
+// FuncInfo is the information of the builder function.
+type FuncInfo struct {
+	Name    string `json:"name"`
+	Summary string `json:"summary,omitempty"`
+
+	// Params is the parameters of the function.
+	//
+	// nil means that the parameters are unknown,
+	// and empty means that the function has no parameters.
+	Params []Param `json:"params"`
+
+	// Examples is the examples of the validation rule using the function.
+	Examples []string `json:"examples,omitempty"`
+
+	// Rule is the expansion of the named rule registered by RegisterRule.
+	Rule string `json:"rule,omitempty"`
+
+	// Cost is the relative cost hint of the validator built by the function,
+	// which is used to order the validators by the optimizer.
+	//
+	// 0 means the default cost, that's, DefaultCost.
+	Cost int `json:"cost,omitempty"`
+
+	// Types is the types of the values supported by the validator
+	// built by the function, which is used by Lint.
+	//
+	// nil means that the validator supports any type.
+	Types []ValueType `json:"types,omitempty"`
+}
+
+// Signature returns the signature of the function, such as
+// "zero", "ranger(min float, max float)", "oneof(...string)",
+// and "name(...)" if the parameters are unknown.
+func (fi FuncInfo) Signature() string {
+	switch {
+	case fi.Params == nil:
+		return fi.Name + "(...)"
+	case len(fi.Params) == 0:
+		return fi.Name
+	}
+
+	params := make([]string, len(fi.Params))
+	for i, p := range fi.Params {
+		params[i] = p.String()
+	}
+	return fmt.Sprintf("%s(%s)", fi.Name, strings.Join(params, ", "))
+}
+
+func (fi FuncInfo) clone() FuncInfo {
+	if fi.Params != nil {
+		fi.Params = append([]Param{}, fi.Params...)
+	}
+	if fi.Examples != nil {
+		fi.Examples = append([]string(nil), fi.Examples...)
+	}
+	if fi.Types != nil {
+		fi.Types = append([]ValueType(nil), fi.Types...)
+	}
+	return fi
+}
+
+// Catalog is the information list of the builder functions.
+type Catalog []FuncInfo
+
+// Catalog returns the catalog of all the builder functions sorted by the name,
+// including those of the parents and the named rules.
+func (b *Builder) Catalog() Catalog {
+	var catalog Catalog
+	b.walkFuncs(func(name string, r *namedRule, info FuncInfo) {
+		info = info.clone()
+		info.Name = name
+		if r != nil {
+			info.Rule = r.rule
+		}
+		catalog = append(catalog, info)
+	})
+
+	sort.Slice(catalog, func(i, j int) bool { return catalog[i].Name < catalog[j].Name })
+	return catalog
+}
+
+// Lookup returns the information of the function by the name.
+func (c Catalog) Lookup(name string) (info FuncInfo, ok bool) {
+	for _, fi := range c {
+		if fi.Name == name {
+			return fi, true
+		}
+	}
+	return
+}
+
+// WriteJSON writes the catalog into w as the JSON array.
+func (c Catalog) WriteJSON(w io.Writer) error {
+	if c == nil {
+		c = Catalog{}
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(c)
+}
+
+// WriteMarkdown writes the catalog into w as the Markdown table.
+func (c Catalog) WriteMarkdown(w io.Writer) (err error) {
+	var b strings.Builder
+	b.WriteString("| Validator | Description | Examples |\n")
+	b.WriteString("| --------- | ----------- | -------- |\n")
+	for _, fi := range c {
+		summary := fi.Summary
+		if fi.Rule != "" {
+			if summary != "" {
+				summary += " "
+			}
+			summary += fmt.Sprintf("=> `%s`", fi.Rule)
+		}
+
+		examples := make([]string, len(fi.Examples))
+		for i, example := range fi.Examples {
+			examples[i] = fmt.Sprintf("`%s`", example)
+		}
+
+		fmt.Fprintf(&b, "| `%s` | %s | %s |\n", escapeMarkdownCell(fi.Signature()),
+			escapeMarkdownCell(summary), escapeMarkdownCell(strings.Join(examples, ", ")))
+	}
+
+	_, err = io.WriteString(w, b.String())
+	return
+}
+
+func escapeMarkdownCell(s string) string {
+	return strings.NewReplacer("|", `\|`, "\n", " ").Replace(s)
+}
@@ -0,0 +1,88 @@
+// Copyright 2025 xgfone
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/xgfone/go-validation/validator/validators"
+)
+
+func ExampleCatalog_WriteMarkdown() {
+	builder := NewBuilder()
+	builder.RegisterFunction(Describe(NameParams(NewFunctionWithTwoFloats("ranger", validators.Ranger), "min", "max"),
+		"the number or length is in [min, max]", "ranger(1, 64)"))
+	builder.RegisterFunction(NewFunctionWithStrings("oneof", validators.OneOf))
+	builder.RegisterFunction(NewFunction("custom", func(*Context, ...any) error { return nil }))
+	_ = builder.RegisterRule("port", "ranger(1, 65535) || oneof(\"any\")")
+
+	_ = builder.Catalog().WriteMarkdown(os.Stdout)
+
+	// Output:
+	// | Validator | Description | Examples |
+	// | --------- | ----------- | -------- |
+	// | `custom(...)` |  |  |
+	// | `oneof(...string)` |  |  |
+	// | `port` | => `ranger(1, 65535) \|\| oneof("any")` |  |
+	// | `ranger(min float, max float)` | the number or length is in [min, max] | `ranger(1, 64)` |
+}
+
+func TestCatalog(t *testing.T) {
+	catalog := DefaultBuilder.Catalog()
+	if len(catalog) != len(DefaultBuilder.GetAllFuncNames()) {
+		t.Errorf("expect %d functions, but got %d", len(DefaultBuilder.GetAllFuncNames()), len(catalog))
+	}
+
+	for _, info := range catalog {
+		if info.Params == nil {
+			t.Errorf("%s: the parameters are unknown", info.Name)
+		}
+	}
+
+	info, ok := catalog.Lookup("exp")
+	if !ok {
+		t.Fatalf("not found the function 'exp'")
+	} else if s := info.Signature(); s != "exp(base int, startExp int, endExp int)" {
+		t.Errorf("unexpected signature '%s'", s)
+	}
+
+	info, _ = catalog.Lookup("array")
+	expect := FuncInfo{
+		Name:     "array",
+		Summary:  "each element of the slice or array is valid",
+		Params:   []Param{{Name: "validators", Type: ParamValidator, Variadic: true}},
+		Examples: []string{"array(min(1))"},
+		Types:    []ValueType{ValueList},
+	}
+	if !reflect.DeepEqual(info, expect) {
+		t.Errorf("expect %+v, but got %+v", expect, info)
+	}
+
+	var buf bytes.Buffer
+	if err := catalog.WriteJSON(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	var infos []FuncInfo
+	if err := json.Unmarshal(buf.Bytes(), &infos); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(Catalog(infos), catalog) {
+		t.Errorf("the catalog is changed after JSON round trip")
+	}
+}
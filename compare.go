@@ -0,0 +1,119 @@
+// Copyright 2025 xgfone
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/xgfone/go-validation/validator/validators"
+	"github.com/xgfone/predicate"
+)
+
+// operand is the built-in identifier resolved against the validated value,
+// which is used as the operand of the comparison operators.
+type operand string
+
+const (
+	operandLen   operand = "len"   // The length of the validated value.
+	operandValue operand = "value" // The validated value itself.
+)
+
+var operands = map[string]operand{
+	string(operandLen):   operandLen,
+	string(operandValue): operandValue,
+}
+
+// reverseOps is used to swap the operands, such as "3 < len" => "len > 3".
+var reverseOps = map[string]string{
+	"==": "==",
+	"!=": "!=",
+	"<":  ">",
+	"<=": ">=",
+	">":  "<",
+	">=": "<=",
+}
+
+func (b *Builder) eq(ctx predicate.BuilderContext, left, right any) error {
+	if isOperand(left) || isOperand(right) {
+		return buildComparison(ctx, "==", left, right)
+	}
+
+	// Support the format "min == 123" or "123 == min"
+	if f, ok := left.(predicate.BuilderFunction); ok {
+		return f(ctx, right)
+	}
+	if f, ok := right.(predicate.BuilderFunction); ok {
+		return f(ctx, left)
+	}
+	return fmt.Errorf("left or right is not BuilderFunction: %T, %T", left, right)
+}
+
+func compare(op string) predicate.BinaryBuilderFunc {
+	return func(ctx predicate.BuilderContext, left, right any) error {
+		return buildComparison(ctx, op, left, right)
+	}
+}
+
+func isOperand(v any) bool {
+	_, ok := v.(operand)
+	return ok
+}
+
+func buildComparison(ctx predicate.BuilderContext, op string, left, right any) (err error) {
+	o, ok := left.(operand)
+	if !ok {
+		if o, ok = right.(operand); !ok {
+			return fmt.Errorf("the comparison '%s' expects len or value as one operand", op)
+		}
+		op, right = reverseOps[op], left
+	}
+
+	if isOperand(right) {
+		return fmt.Errorf("the comparison '%s' does not support two operands of len or value", op)
+	}
+
+	switch o {
+	case operandLen:
+		var n int
+		switch v := right.(type) {
+		case int:
+			n = v
+		case float64:
+			if v != math.Trunc(v) || v < math.MinInt || v >= math.MaxInt {
+				return fmt.Errorf("len expects to be compared with an integer, but got %v", v)
+			}
+			n = int(v)
+		default:
+			return fmt.Errorf("len expects to be compared with an integer, but got %T", right)
+		}
+		ctx.(*Context).AppendValidators(validators.Len(op, n))
+
+	case operandValue:
+		switch right.(type) {
+		case int, float64, time.Duration, string, bool:
+		default:
+			return fmt.Errorf("value expects to be compared with an int, float, duration, string or bool, but got %T", right)
+		}
+
+		if _, ok := right.(bool); ok && op != "==" && op != "!=" {
+			return fmt.Errorf("value does not support the comparison '%s' with a bool", op)
+		}
+		ctx.(*Context).AppendValidators(validators.Value(op, right))
+	}
+
+	return
+}
@@ -21,6 +21,7 @@ import (
 
 // Context is a builder context to manage the built validators.
 type Context struct {
+	builder    *Builder
 	validators []validator.Validator
 }
 
@@ -28,11 +29,22 @@ type Context struct {
 func NewContext() *Context { return &Context{} }
 
 // New implements the interface predicate.BuidlerContext.
-func (c *Context) New() predicate.BuilderContext { return NewContext() }
+//
+// The new context inherits the builder of c.
+func (c *Context) New() predicate.BuilderContext { return &Context{builder: c.builder} }
+
+// Builder returns the builder building the rule into the context,
+// which may be a child builder extended from the builder registering
+// the building function. So the function can use it to build the sub-rules.
+//
+// Return nil if the context is not used by any builder.
+func (c *Context) Builder() *Builder { return c.builder }
 
 // Not implements the interface predicate.BuidlerContext.
-func (c *Context) Not(predicate.BuilderContext) {
-	panic("unsupport the NOT validation rule")
+func (c *Context) Not(bc predicate.BuilderContext) {
+	if validators := bc.(*Context).Validators(); len(validators) > 0 {
+		c.AppendValidators(validator.Not(validator.And(validators...), nil))
+	}
 }
 
 // And implements the interface predicate.BuidlerContext.
@@ -16,6 +16,7 @@ package validation
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/xgfone/go-validation/validator"
 	"github.com/xgfone/go-validation/validator/str"
@@ -48,13 +49,18 @@ func init() { RegisterDefaultsForBuilder(DefaultBuilder) }
 //	isnumber() or isnumber
 //	duration() or duration
 //	required() or required
-//	structure() or structure
+//	structure() or structure: validate the struct fields by the tag "validate".
 //	exp(base, startExp, endExp int)
 //	min(float64)
 //	max(float64)
 //	ranger(min, max float64)
-//	time(formatLayout string)
+//	time(formatLayout string, loc=location string)
+//	within(d duration)
 //	oneof(...string)
+//	istype(...string)
+//	optional(...Validator)
+//	if(cond, then Validator, else ...Validator)
+//	when(cond, then Validator, else ...Validator)
 //	array(...Validator)
 //	mapkv(...Validator)
 //	mapk(...Validator)
@@ -65,6 +71,23 @@ func init() { RegisterDefaultsForBuilder(DefaultBuilder) }
 //	posixregexp(rule string)
 //	regexp(rule string)
 //	self() or self: the validated value must have implemented validator.ValueValidator.
+//	eqfield(field string)
+//	nefield(field string)
+//	gtfield(field string)
+//	gtefield(field string)
+//	ltfield(field string)
+//	ltefield(field string)
+//	required_if(field1, value1, ...)
+//	required_unless(field1, value1, ...)
+//	excluded_if(field1, value1, ...)
+//	excluded_unless(field1, value1, ...)
+//	required_with(...string)
+//	required_without(...string)
+//	excluded_with(...string)
+//	excluded_without(...string)
+//
+// The cross-field validators, such as eqfield and required_if, refer to
+// the sibling fields of the struct that contains the validated field.
 func RegisterDefaultsForBuilder(b *Builder) {
 	b.RegisterSymbol("timelayout", "15:04:05")
 	b.RegisterSymbol("datelayout", "2006-01-02")
@@ -73,51 +96,194 @@ func RegisterDefaultsForBuilder(b *Builder) {
 	registerTimeValidator(b, "dateformat", "2006-01-02")
 	registerTimeValidator(b, "datetimeformat", "2006-01-02 15:04:05")
 
-	b.RegisterFunction(NewFunctionWithoutArgs("zero", validators.Zero))
-	b.RegisterFunction(NewFunctionWithoutArgs("empty", validators.Empty))
-	b.RegisterFunction(NewFunctionWithoutArgs("notzero", validators.NotZero))
-	b.RegisterFunction(NewFunctionWithoutArgs("notempty", validators.NotEmpty))
-	b.RegisterFunction(NewFunctionWithoutArgs("required", validators.Required))
-	b.RegisterFunction(NewFunctionWithoutArgs("isnumber", validators.IsNumber))
-	b.RegisterFunction(NewFunctionWithoutArgs("isinteger", validators.IsInteger))
-
-	b.RegisterFunction(NewFunctionWithoutArgs("ip", validators.IP))
-	b.RegisterFunction(NewFunctionWithoutArgs("mac", validators.Mac))
-	b.RegisterFunction(NewFunctionWithoutArgs("url", validators.Url))
-	b.RegisterFunction(NewFunctionWithoutArgs("cidr", validators.Cidr))
-	b.RegisterFunction(NewFunctionWithoutArgs("addr", validators.Addr))
-
-	b.RegisterFunction(NewFunctionWithOneFloat("min", validators.Min))
-	b.RegisterFunction(NewFunctionWithOneFloat("max", validators.Max))
-	b.RegisterFunction(NewFunctionWithTwoFloats("ranger", validators.Ranger))
-	b.RegisterFunction(NewFunctionWithThreeInts("exp", validators.Exp))
-
-	b.RegisterFunction(NewFunctionWithOneString("time", validators.Time))
-	b.RegisterFunction(NewFunctionWithoutArgs("duration", validators.Duration))
-
-	b.RegisterFunction(NewFunctionWithOneString("regexp", validators.Regexp))
-	b.RegisterFunction(NewFunctionWithOneString("posixregexp", validators.RegexpPOSIX))
-
-	b.RegisterFunction(NewFunctionWithStrings("oneof", validators.OneOf))
-	b.RegisterFunction(NewFunctionWithValidators("array", validators.Array))
-	b.RegisterFunction(NewFunctionWithValidators("mapk", validators.MapK))
-	b.RegisterFunction(NewFunctionWithValidators("mapv", validators.MapV))
-	b.RegisterFunction(NewFunctionWithValidators("mapkv", validators.MapKV))
-
-	b.RegisterValidatorFunc("self", func(value any) (err error) {
+	register := func(f Function, summary string, examples ...string) {
+		if types, ok := defaultValueTypes[f.Name()]; ok {
+			f = WithTypes(f, types...)
+		}
+		b.RegisterFunction(Describe(f, summary, examples...))
+	}
+
+	register(NewFunctionWithoutArgs("zero", validators.Zero), "the value is ZERO", "zero || min(1)")
+	register(NewFunctionWithoutArgs("empty", validators.Empty), "the value is ZERO, same as zero", "empty")
+	register(NewFunctionWithoutArgs("notzero", validators.NotZero), "the value is not ZERO", "notzero")
+	register(NewFunctionWithoutArgs("notempty", validators.NotEmpty), "the value is not ZERO, same as notzero", "notempty")
+	register(NewFunctionWithoutArgs("required", validators.Required), "the value is not ZERO", "required")
+	register(NewFunctionWithoutArgs("isnumber", validators.IsNumber), "the string is an integer or float", "isnumber")
+	register(NewFunctionWithoutArgs("isinteger", validators.IsInteger), "the string is an integer", "isinteger")
+
+	register(NewFunctionWithoutArgs("ip", validators.IP), "the string is an IPv4 or IPv6 address", "ip")
+	register(NewFunctionWithoutArgs("mac", validators.Mac), "the string is a 48-bit MAC address", "mac")
+	register(WithCost(NewFunctionWithoutArgs("url", validators.Url), 20), "the string is a URL", "url")
+	register(NewFunctionWithoutArgs("cidr", validators.Cidr), "the string is a CIDR", "cidr")
+	register(NewFunctionWithoutArgs("addr", validators.Addr), "the string is an address like HOST:PORT", "addr")
+
+	register(NameParams(NewFunctionWithOneFloat("min", validators.Min), "min"),
+		"the number or length is not less than min", "min(1)")
+	register(NameParams(NewFunctionWithOneFloat("max", validators.Max), "max"),
+		"the number or length is not greater than max", "max(64)")
+	register(NameParams(NewFunctionWithTwoFloats("ranger", validators.Ranger), "min", "max"),
+		"the number or length is in [min, max]", "ranger(1, 64)")
+	register(NameParams(NewFunctionWithThreeInts("exp", validators.Exp), "base", "startExp", "endExp"),
+		"the integer is a power of base with the exponent in [startExp, endExp]", "exp(2, 0, 10)")
+
+	register(WithCost(NameParams(newTimeFunction(), "layout"), 20),
+		"the string is a time in the layout, which is parsed in the location loc if given",
+		`time("2006-01-02")`, "time(datelayout)", `time("2006-01-02 15:04", loc="Asia/Shanghai")`)
+	register(NameParams(NewFunctionFrom("within", validators.Within), "d"),
+		"the time is within the duration d before or after now", "within(24h)")
+	register(NewFunctionWithoutArgs("duration", validators.Duration),
+		"the string is a duration parsed by time.ParseDuration", "duration")
+
+	register(WithCost(newRegexpFunction("regexp", validators.Regexp), 50),
+		"the string matches the regular expression", `regexp("^[a-z]+$")`)
+	register(WithCost(newRegexpFunction("posixregexp", validators.RegexpPOSIX), 50),
+		"the string matches the POSIX regular expression", `posixregexp("^[a-z]+$")`)
+
+	register(NameParams(NewFunctionWithStrings("oneof", validators.OneOf), "values"),
+		"the value is one of the values", `oneof("a", "b", "c")`)
+	register(NameParams(NewFunctionWithStrings("istype", validators.IsType), "types"),
+		"the type of the value is one of the types, such as string, integer, number, etc",
+		`istype("string")`, `istype("integer", "float")`)
+	register(NameParams(NewFunctionWithValidators("optional", optional), "validators"),
+		"skip the validation if the value is ZERO, or validate it by the validators",
+		`optional(min(8) && max(64))`)
+	register(NameParams(newIfFunction("if"), "cond", "then", "else"),
+		"validate the value by then if it satisfies cond, or else if given",
+		`if(istype("string"), max(64), ranger(0, 100))`)
+	register(NameParams(newIfFunction("when"), "cond", "then", "else"),
+		"the alias of if", "when(zero, true, min(8))")
+	register(NameParams(NewFunctionWithValidators("array", validators.Array), "validators"),
+		"each element of the slice or array is valid", "array(min(1))")
+	register(NameParams(NewFunctionWithValidators("mapk", validators.MapK), "validators"),
+		"each key of the map is valid", "mapk(min(1))")
+	register(NameParams(NewFunctionWithValidators("mapv", validators.MapV), "validators"),
+		"each value of the map is valid", "mapv(min(1))")
+	register(NameParams(NewFunctionWithValidators("mapkv", validators.MapKV), "validators"),
+		"each key-value pair of the map is valid", "mapkv(self)")
+
+	register(NameParams(NewFunctionWithOneString("eqfield", validators.EqField), "field"),
+		"the value is equal to the sibling field", `eqfield("Password")`)
+	register(NameParams(NewFunctionWithOneString("nefield", validators.NeField), "field"),
+		"the value is not equal to the sibling field", `nefield("OldPassword")`)
+	register(NameParams(NewFunctionWithOneString("gtfield", validators.GtField), "field"),
+		"the value is greater than the sibling field", `gtfield("Start")`)
+	register(NameParams(NewFunctionWithOneString("gtefield", validators.GteField), "field"),
+		"the value is greater than or equal to the sibling field", `gtefield("Min")`)
+	register(NameParams(NewFunctionWithOneString("ltfield", validators.LtField), "field"),
+		"the value is less than the sibling field", `ltfield("End")`)
+	register(NameParams(NewFunctionWithOneString("ltefield", validators.LteField), "field"),
+		"the value is less than or equal to the sibling field", `ltefield("Max")`)
+	register(newFieldPairsFunction("required_if", validators.RequiredIf),
+		"the value is not ZERO if all the sibling fields are equal to the values", `required_if("Type", "card")`)
+	register(newFieldPairsFunction("required_unless", validators.RequiredUnless),
+		"the value is not ZERO unless all the sibling fields are equal to the values", `required_unless("Type", "cash")`)
+	register(newFieldPairsFunction("excluded_if", validators.ExcludedIf),
+		"the value is ZERO if all the sibling fields are equal to the values", `excluded_if("Type", "cash")`)
+	register(newFieldPairsFunction("excluded_unless", validators.ExcludedUnless),
+		"the value is ZERO unless all the sibling fields are equal to the values", `excluded_unless("Type", "card")`)
+	register(newFieldsFunction("required_with", validators.RequiredWith),
+		"the value is not ZERO if any of the sibling fields is not ZERO", `required_with("Phone")`)
+	register(newFieldsFunction("required_without", validators.RequiredWithout),
+		"the value is not ZERO if any of the sibling fields is ZERO", `required_without("Email")`)
+	register(newFieldsFunction("excluded_with", validators.ExcludedWith),
+		"the value is ZERO if any of the sibling fields is not ZERO", `excluded_with("Phone")`)
+	register(newFieldsFunction("excluded_without", validators.ExcludedWithout),
+		"the value is ZERO if any of the sibling fields is ZERO", `excluded_without("Email")`)
+
+	register(WithCost(newStructFunction(b), 100),
+		`validate the struct fields by the tag "validate"`, "structure", "array(structure)")
+	register(WithCost(ValidatorFunction("self", validator.NewValidator("self", func(value any) (err error) {
 		return value.(validator.ValueValidator).Validate()
-	})
+	})), 100), "the value has implemented validator.ValueValidator", "self")
+}
+
+// defaultValueTypes is the types of the values supported by the default
+// validators, and those not in it support any type.
+var defaultValueTypes = map[string][]ValueType{
+	"isnumber":    {ValueString},
+	"isinteger":   {ValueString},
+	"ip":          {ValueString},
+	"mac":         {ValueString},
+	"url":         {ValueString},
+	"cidr":        {ValueString},
+	"addr":        {ValueString},
+	"min":         {ValueNumber, ValueString, ValueList, ValueMap},
+	"max":         {ValueNumber, ValueString, ValueList, ValueMap},
+	"ranger":      {ValueNumber, ValueString, ValueList, ValueMap},
+	"exp":         {ValueNumber},
+	"time":        {ValueString},
+	"within":      {ValueTime},
+	"duration":    {ValueString},
+	"regexp":      {ValueString},
+	"posixregexp": {ValueString},
+	"oneof":       {ValueString},
+	"array":       {ValueList},
+	"mapk":        {ValueMap},
+	"mapv":        {ValueMap},
+	"mapkv":       {ValueMap},
+	"structure":   {ValueStruct},
 }
 
 func registerTimeValidator(b *Builder, name, layout string) {
-	b.RegisterFunction(NewFunctionWithoutArgs(name, func() validator.Validator {
+	f := NewFunctionWithoutArgs(name, func() validator.Validator {
 		return validators.Time(layout)
-	}))
+	})
+	f = WithTypes(f, ValueString)
+	b.RegisterFunction(Describe(f, fmt.Sprintf("the string is a time in the layout %q", layout), name))
+}
+
+func newRegexpFunction(name string, newf func(string) validator.Validator) Function {
+	f := NewFunctionWithOneString(name, newf)
+	info := GetFuncInfo(f)
+	info.Params = []Param{{Name: "pattern", Type: ParamRegexp}}
+	return NewFunctionWithInfo(info, f.Call)
+}
+
+func newFieldsFunction(name string, newf func(...string) validator.Validator) Function {
+	f := NewFunctionWithStrings(name, newf)
+	params := []Param{{Name: "fields", Type: ParamString, Variadic: true}}
+	return newFunction(name, params, func(c *Context, args ...any) error {
+		if len(args) == 0 {
+			return fmt.Errorf("%s needs at least one field", name)
+		}
+		return f.Call(c, args...)
+	})
+}
+
+// newFieldPairsFunction returns a new Function with the pairs of the field
+// name and value, and the value may be a string, integer or float.
+func newFieldPairsFunction(name string, newf func(...string) validator.Validator) Function {
+	params := []Param{{Name: "fieldValues", Type: ParamAny, Variadic: true}}
+	return newFunction(name, params, func(c *Context, args ...any) error {
+		if len(args) == 0 || len(args)%2 != 0 {
+			return fmt.Errorf("%s expects the pairs of the field and value", name)
+		}
+
+		vs := make([]string, len(args))
+		for i, arg := range args {
+			switch v := arg.(type) {
+			case string:
+				vs[i] = v
+			case int, float64:
+				if i%2 == 0 {
+					return fmt.Errorf("%s expects %dth argument is a field name, but got %T", name, i, arg)
+				}
+				vs[i] = fmt.Sprint(v)
+			default:
+				return fmt.Errorf("%s expects %dth argument is a string or number, but got %T", name, i, arg)
+			}
+		}
+
+		c.AppendValidators(newf(vs...))
+		return nil
+	})
 }
 
 // RegisterStringValidatorsForBuilder registers some string validators,
 // that's, the value is a specific string.
 //
+// See StrPack for the same validators in the namespace "str".
+//
 //	isascii: [\x00-\x7F]+
 //	isalpha: [a-zA-Z]+
 //	isalphanumeric: [a-zA-Z0-9]+
@@ -181,71 +347,129 @@ func registerTimeValidator(b *Builder, name, layout string) {
 //	isuuid5
 //	isuppercase
 func RegisterStringValidatorsForBuilder(b *Builder) {
-	registerStrValidator(b, str.IsASCII, "ascii")
-	registerStrValidator(b, str.IsAlpha, "alpha")
-	registerStrValidator(b, str.IsAlphanumeric, "alphanumeric")
-	registerStrValidator(b, str.IsBase64, "base64")
-	registerStrValidator(b, str.IsCRC32, "crc32")
-	registerStrValidator(b, str.IsCRC32b, "crc64")
-	registerStrValidator(b, str.IsDNSName, "dnsname")
-	registerStrValidator(b, str.IsDataURI, "datauri")
-	registerStrValidator(b, str.IsE164, "e164")
-	registerStrValidator(b, str.IsEmail, "email")
-	registerStrValidator(b, str.IsExistingEmail, "existingemail")
-	registerStrValidator(b, str.IsFloat, "float")
-	registerStrValidator(b, str.IsHexadecimal, "hexadecimal")
-	registerStrValidator(b, str.IsHexcolor, "hexcolor")
-	registerStrValidator(b, str.IsHost, "host")
-	registerStrValidator(b, str.IsIMEI, "imei")
-	registerStrValidator(b, str.IsIMSI, "imsi")
-	registerStrValidator(b, str.IsIPv4, "ipv4")
-	registerStrValidator(b, str.IsIPv6, "ipv6")
-	registerStrValidator(b, str.IsISBN10, "isbn10")
-	registerStrValidator(b, str.IsISBN13, "isbn13")
-	registerStrValidator(b, str.IsInt, "int")
-	registerStrValidator(b, str.IsJSON, "json")
-	registerStrValidator(b, str.IsLatitude, "latitude")
-	registerStrValidator(b, str.IsLongitude, "longitude")
-	registerStrValidator(b, str.IsLowerCase, "lowercase")
-	registerStrValidator(b, str.IsMD4, "md4")
-	registerStrValidator(b, str.IsMD5, "md5")
-	registerStrValidator(b, str.IsMagnetURI, "magneturi")
-	registerStrValidator(b, str.IsMongoID, "mongoid")
-	registerStrValidator(b, str.IsPrintableASCII, "printableascii")
-	registerStrValidator(b, str.IsRFC3339, "rfc3390")
-	registerStrValidator(b, str.IsRFC3339WithoutZone, "rfc3390withoutzone")
-	registerStrValidator(b, str.IsRGBcolor, "rgbcolor")
-	registerStrValidator(b, str.IsRequestURI, "requesturi")
-	registerStrValidator(b, str.IsRequestURL, "requesturl")
-	registerStrValidator(b, str.IsRipeMD128, "ripemd128")
-	registerStrValidator(b, str.IsRipeMD160, "ripemd160")
-	registerStrValidator(b, str.IsSHA1, "sha1")
-	registerStrValidator(b, str.IsSHA256, "sha256")
-	registerStrValidator(b, str.IsSHA3224, "sha3224")
-	registerStrValidator(b, str.IsSHA3256, "sha3256")
-	registerStrValidator(b, str.IsSHA3384, "sha3384")
-	registerStrValidator(b, str.IsSHA3512, "sha3512")
-	registerStrValidator(b, str.IsSHA384, "sha384")
-	registerStrValidator(b, str.IsSHA512, "sha512")
-	registerStrValidator(b, str.IsSSN, "ssn")
-	registerStrValidator(b, str.IsSemver, "semver")
-	registerStrValidator(b, str.IsTiger128, "tiger128")
-	registerStrValidator(b, str.IsTiger160, "tiger160")
-	registerStrValidator(b, str.IsTiger192, "tiger192")
-	registerStrValidator(b, str.IsULID, "ulid")
-	registerStrValidator(b, str.IsURL, "url")
-	registerStrValidator(b, str.IsUTFDigit, "utfdigit")
-	registerStrValidator(b, str.IsUTFLetter, "utfletter")
-	registerStrValidator(b, str.IsUTFLetterNumeric, "utfletternumeric")
-	registerStrValidator(b, str.IsUTFNumeric, "utfnumeric")
-	registerStrValidator(b, str.IsUUID, "uuid")
-	registerStrValidator(b, str.IsUUIDv3, "uuid3")
-	registerStrValidator(b, str.IsUUIDv4, "uuid4")
-	registerStrValidator(b, str.IsUUIDv5, "uuid5")
-	registerStrValidator(b, str.IsUpperCase, "uppercase")
+	for _, v := range strValidators {
+		registerStrValidator(b, v.f, v.name)
+	}
+}
+
+var strValidators = []struct {
+	name string
+	f    func(string) bool
+}{
+	{"ascii", str.IsASCII},
+	{"alpha", str.IsAlpha},
+	{"alphanumeric", str.IsAlphanumeric},
+	{"base64", str.IsBase64},
+	{"crc32", str.IsCRC32},
+	{"crc64", str.IsCRC32b},
+	{"dnsname", str.IsDNSName},
+	{"datauri", str.IsDataURI},
+	{"e164", str.IsE164},
+	{"email", str.IsEmail},
+	{"existingemail", str.IsExistingEmail},
+	{"float", str.IsFloat},
+	{"hexadecimal", str.IsHexadecimal},
+	{"hexcolor", str.IsHexcolor},
+	{"host", str.IsHost},
+	{"imei", str.IsIMEI},
+	{"imsi", str.IsIMSI},
+	{"ipv4", str.IsIPv4},
+	{"ipv6", str.IsIPv6},
+	{"isbn10", str.IsISBN10},
+	{"isbn13", str.IsISBN13},
+	{"int", str.IsInt},
+	{"json", str.IsJSON},
+	{"latitude", str.IsLatitude},
+	{"longitude", str.IsLongitude},
+	{"lowercase", str.IsLowerCase},
+	{"md4", str.IsMD4},
+	{"md5", str.IsMD5},
+	{"magneturi", str.IsMagnetURI},
+	{"mongoid", str.IsMongoID},
+	{"printableascii", str.IsPrintableASCII},
+	{"rfc3390", str.IsRFC3339},
+	{"rfc3390withoutzone", str.IsRFC3339WithoutZone},
+	{"rgbcolor", str.IsRGBcolor},
+	{"requesturi", str.IsRequestURI},
+	{"requesturl", str.IsRequestURL},
+	{"ripemd128", str.IsRipeMD128},
+	{"ripemd160", str.IsRipeMD160},
+	{"sha1", str.IsSHA1},
+	{"sha256", str.IsSHA256},
+	{"sha3224", str.IsSHA3224},
+	{"sha3256", str.IsSHA3256},
+	{"sha3384", str.IsSHA3384},
+	{"sha3512", str.IsSHA3512},
+	{"sha384", str.IsSHA384},
+	{"sha512", str.IsSHA512},
+	{"ssn", str.IsSSN},
+	{"semver", str.IsSemver},
+	{"tiger128", str.IsTiger128},
+	{"tiger160", str.IsTiger160},
+	{"tiger192", str.IsTiger192},
+	{"ulid", str.IsULID},
+	{"url", str.IsURL},
+	{"utfdigit", str.IsUTFDigit},
+	{"utfletter", str.IsUTFLetter},
+	{"utfletternumeric", str.IsUTFLetterNumeric},
+	{"utfnumeric", str.IsUTFNumeric},
+	{"uuid", str.IsUUID},
+	{"uuid3", str.IsUUIDv3},
+	{"uuid4", str.IsUUIDv4},
+	{"uuid5", str.IsUUIDv5},
+	{"uppercase", str.IsUpperCase},
+}
+
+// strValidatorCosts is the cost hints of the expensive string validators.
+var strValidatorCosts = map[string]int{
+	"existingemail": 1000, // Look up the MX records by DNS.
 }
 
 func registerStrValidator(b *Builder, f func(string) bool, name string) {
+	b.RegisterFunction(newStrFunction("is"+name, "is"+name, name, f))
+}
+
+// newStrFunction returns a new Function with the function name fname
+// to build the string validator with the rule, such as "str.email"
+// for the function "email" registered in the namespace "str".
+func newStrFunction(fname, rule, name string, f func(string) bool) Function {
 	err := fmt.Errorf("the string is not %s", name)
-	b.RegisterValidatorFunc("is"+name, validator.BoolValidateFunc(f, err))
+	v := validator.NewValidator(rule, validator.BoolValidateFunc(f, err))
+	return WithTypes(WithCost(ValidatorFunction(fname, v), strValidatorCosts[name]), ValueString)
+}
+
+func registerStrValidatorFunc(register func(string, validator.ValidateFunc), f func(string) bool, name string) {
+	err := fmt.Errorf("the string is not %s", name)
+	register(name, validator.BoolValidateFunc(f, err))
+}
+
+func newTimeFunction() Function {
+	return NewFunctionFrom("time", func(layout string, opts struct{ Loc string }) (validator.Validator, error) {
+		if opts.Loc == "" {
+			return validators.Time(layout), nil
+		}
+
+		loc, err := time.LoadLocation(opts.Loc)
+		if err != nil {
+			return nil, err
+		}
+		return validators.TimeIn(layout, loc), nil
+	})
+}
+
+func newIfFunction(name string) Function {
+	return NewFunctionFrom(name, func(cond, then validator.Validator, otherwise ...validator.Validator) (validator.Validator, error) {
+		switch len(otherwise) {
+		case 0:
+			return validator.If(cond, then, nil), nil
+		case 1:
+			return validator.If(cond, then, otherwise[0]), nil
+		default:
+			return nil, fmt.Errorf("%s expects at most 3 arguments, but got %d", name, len(otherwise)+2)
+		}
+	})
+}
+
+func optional(validators ...validator.Validator) validator.Validator {
+	return validator.Optional(validator.And(validators...))
 }
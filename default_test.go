@@ -16,7 +16,11 @@ package validation
 
 import (
 	"errors"
+	"fmt"
+	"strings"
 	"testing"
+
+	"github.com/xgfone/go-validation/validator"
 )
 
 func TestUrlValidation(t *testing.T) {
@@ -86,3 +90,139 @@ func TestSelfValidator(t *testing.T) {
 		t.Errorf("expect nil, but got an error: %v", err)
 	}
 }
+
+func TestConditionalValidator(t *testing.T) {
+	tests := []struct {
+		value any
+		rule  string
+		valid bool
+	}{
+		{"", "when(zero, true, min(8))", true},
+		{"abc", "when(zero, true, min(8))", false},
+		{"abcdefgh", "when(zero, true, min(8))", true},
+		{"abc", `if(istype("string"), max(64), ranger(0, 100))`, true},
+		{200, `if(istype("string"), max(64), ranger(0, 100))`, false},
+		{50, `if(istype("string"), max(64), ranger(0, 100))`, true},
+		{50, `if(istype("string"), max(64))`, true},
+		{"abc", `if(istype("string"), false)`, false},
+	}
+
+	for _, test := range tests {
+		err := Validate(test.value, test.rule)
+		if test.valid && err != nil {
+			t.Errorf("%s: %v: unexpected error: %v", test.rule, test.value, err)
+		} else if !test.valid && err == nil {
+			t.Errorf("%s: %v: expect an error, but got nil", test.rule, test.value)
+		}
+	}
+
+	err := Validate(200, `if(istype("string"), max(64), ranger(0, 100))`)
+	if err == nil || err.Error() != "the integer is not in range [0, 100]" {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	v, _ := DefaultBuilder.BuildValidator("when(zero, true, min(8))")
+	if s := v.String(); s != "if(zero, true, min(8))" {
+		t.Errorf("unexpected rule '%s'", s)
+	}
+
+	for rule, expect := range map[string]string{
+		"if(zero, true, true, true)": "if expects at most 3 arguments, but got 4",
+		"if(zero)":                   "if expects at least 2 arguments, but got 1",
+		`istype("unknown")`:          "istype: unsupported type 'unknown'",
+	} {
+		if err := TryValidate("", rule); err == nil || !strings.HasSuffix(err.Error(), expect) {
+			t.Errorf("%s: expect error '%s', but got '%v'", rule, expect, err)
+		}
+	}
+}
+
+func TestOptionalValidator(t *testing.T) {
+	const rule = "optional(min(8) && max(64))"
+	if err := Validate("", rule); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := Validate("abc", rule); err == nil || err.Error() != "the string length is less than 8" {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := Validate("abcdefgh", rule); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestDefaultsRoundTrip(t *testing.T) {
+	var rules []string
+	for _, fi := range DefaultBuilder.Catalog() {
+		rules = append(rules, fi.Examples...)
+	}
+
+	rules = append(rules,
+		`regexp("^\"[a-z]\\d+\"$")`, `posixregexp("a\\.b")`, `oneof("a\"", "<b>", "c\n", "")`,
+		`time("2006\"01\\02", loc="UTC")`, `min(1e21)`, `max(-0.5)`, `ranger(-1.5, 1e30)`,
+		`within(1.5s)`, `value == "a\"b"`, `value > 2.5`, `value != 1.5s`, `value == true`,
+		`len >= 1e9`, `!(len > 1)`, `!!zero`, `!zero && (min(1) || max(2))`, `!(zero && min(1))`,
+		`when(zero, false)`, `optional(min(1) || max(2))`, `array(min(1), max(2))`,
+		`required_if("A\"", "x\\y")`, `mapkv(mapv(array(oneof("a b", "c,d"))))`,
+	)
+
+	for _, rule := range rules {
+		v1, err := DefaultBuilder.BuildValidator(rule)
+		if err != nil {
+			t.Errorf("%s: %v", rule, err)
+			continue
+		}
+
+		s := v1.String()
+		v2, err := DefaultBuilder.BuildValidator(s)
+		if err != nil {
+			t.Errorf("%s: fail to rebuild '%s': %v", rule, s, err)
+			continue
+		}
+
+		if s2 := v2.String(); s2 != s {
+			t.Errorf("%s: expect '%s', but got '%s'", rule, s, s2)
+		}
+		if n1, n2 := dumpNodes(v1), dumpNodes(v2); n1 != n2 {
+			t.Errorf("%s: expect the nodes '%s', but got '%s'", rule, n1, n2)
+		}
+	}
+}
+
+func dumpNodes(v validator.Validator) string {
+	var b strings.Builder
+	validator.Walk(v, func(n validator.Node) bool {
+		fmt.Fprintf(&b, "%s:%s%#v;", n.Kind(), n.Name(), n.Args())
+		return true
+	})
+	return b.String()
+}
+
+func TestNotRoundTrip(t *testing.T) {
+	b := DefaultBuilder.Extend()
+	b.RegisterValidator("minmax", validator.NewValidator("max(1) || min(3)", func(v any) error {
+		return DefaultBuilder.Validate(v, "max(1) || min(3)")
+	}))
+
+	v1, err := b.BuildValidator("!minmax")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := v1.String()
+	if s != "!(max(1) || min(3))" {
+		t.Errorf("expect '%s', but got '%s'", "!(max(1) || min(3))", s)
+	}
+
+	v2, err := b.BuildValidator(s)
+	if err != nil {
+		t.Fatalf("fail to rebuild '%s': %v", s, err)
+	} else if s2 := v2.String(); s2 != s {
+		t.Errorf("expect '%s', but got '%s'", s, s2)
+	}
+
+	for _, value := range []any{0, 1, 2, 3} {
+		if err1, err2 := v1.Validate(value), v2.Validate(value); (err1 == nil) != (err2 == nil) {
+			t.Errorf("%v: expect the error '%v', but got '%v'", value, err1, err2)
+		}
+	}
+}
@@ -0,0 +1,178 @@
+// Copyright 2025 xgfone
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// ErrInvalidRule is the base error that the validation rule is invalid,
+// which is used to distinguish from the validation failures, such as
+//
+//	if errors.Is(err, ErrInvalidRule) {
+//		// The rule fails to be built.
+//	}
+var ErrInvalidRule = errors.New("invalid validation rule")
+
+// RuleError represents the error that the validation rule fails to be built,
+// such as the syntax error, the undefined function, etc.
+type RuleError struct {
+	Name string // The name of the rule, which may be empty.
+	Rule string // The validation rule.
+	Err  error  // The error to parse and build the rule.
+}
+
+// NewRuleError returns a new RuleError.
+func NewRuleError(name, rule string, err error) *RuleError {
+	return &RuleError{Name: name, Rule: rule, Err: err}
+}
+
+// Error implements the interface error.
+func (e *RuleError) Error() string {
+	if e.Name != "" {
+		return fmt.Sprintf("invalid rule '%s': %v", e.Name, e.Err)
+	}
+	return fmt.Sprintf("invalid rule '%s': %v", e.Rule, e.Err)
+}
+
+// Unwrap returns the inner error.
+func (e *RuleError) Unwrap() error { return e.Err }
+
+// Is reports whether the target is ErrInvalidRule, which is used by errors.Is.
+func (e *RuleError) Is(target error) bool { return target == ErrInvalidRule }
+
+// PosError represents the error at the position of the validation rule,
+// such as the syntax error, the error to build the function call, etc.
+type PosError struct {
+	Offset int    // The byte offset in the rule, starting at 0.
+	Line   int    // The line number, starting at 1.
+	Column int    // The column number in bytes, starting at 1.
+	Token  string // The offending token or node of the rule, which may be empty.
+	Err    error  // The original error.
+
+	end int // The byte offset immediately after the offending node.
+}
+
+// newPosError returns a new PosError of the offending node,
+// the line, column and token of which are located by the rule later.
+func newPosError(node ruleNode, err error) *PosError {
+	return &PosError{Offset: node.Pos(), end: node.End(), Err: err}
+}
+
+// Error implements the interface error.
+func (e *PosError) Error() string {
+	return fmt.Sprintf("%d:%d: %v", e.Line, e.Column, e.Err)
+}
+
+// Unwrap returns the inner error.
+func (e *PosError) Unwrap() error { return e.Err }
+
+// locate sets the line, column and token of the offending node in the rule.
+func (e *PosError) locate(rule string) {
+	if e.Line > 0 || e.Offset > len(rule) {
+		return
+	}
+
+	before := rule[:e.Offset]
+	e.Line = strings.Count(before, "\n") + 1
+	e.Column = e.Offset - strings.LastIndexByte(before, '\n')
+	if e.Offset < e.end && e.end <= len(rule) {
+		e.Token = rule[e.Offset:e.end]
+	}
+}
+
+// wrapPosError wraps the error with the position of the offending node
+// if it has not been wrapped by PosError.
+func wrapPosError(node ruleNode, err error) error {
+	var perr *PosError
+	if err == nil || errors.As(err, &perr) {
+		return err
+	}
+	return newPosError(node, err)
+}
+
+// locatePosError locates the position of PosError in err by the rule.
+func locatePosError(rule string, err error) error {
+	var perr *PosError
+	if errors.As(err, &perr) {
+		perr.locate(rule)
+	}
+	return err
+}
+
+// FormatError formats the error of the rule in the caret style, which
+// underlines the offending token of the rule if err contains PosError,
+// such as
+//
+//	min(1) && max(1, 2)
+//	          ^^^^^^^^^
+//	1:11: max must have and only have one argument
+//
+// Or, return err.Error() only.
+//
+// For the rule referring to the variables, the position is relative to
+// the sub-rule in RuleError, which is built when validating the value.
+func FormatError(rule string, err error) string {
+	var perr *PosError
+	if !errors.As(err, &perr) || perr.Line <= 0 || perr.Offset > len(rule) {
+		return err.Error()
+	}
+
+	start := perr.Offset - perr.Column + 1
+	line := rule[start:]
+	if i := strings.IndexByte(line, '\n'); i > -1 {
+		line = line[:i]
+	}
+	if perr.Column-1 > len(line) { // The error is not of the rule.
+		return err.Error()
+	}
+
+	token := perr.Token
+	if i := strings.IndexByte(token, '\n'); i > -1 {
+		token = token[:i]
+	}
+
+	width := utf8.RuneCountInString(token)
+	if width == 0 {
+		width = 1
+	}
+
+	var b strings.Builder
+	b.Grow(len(line)*2 + 64)
+	b.WriteString(line)
+	b.WriteByte('\n')
+	for _, c := range line[:perr.Column-1] {
+		if c == '\t' { // Keep the tab to align the caret.
+			b.WriteByte('\t')
+		} else {
+			b.WriteByte(' ')
+		}
+	}
+	b.WriteString(strings.Repeat("^", width))
+	b.WriteByte('\n')
+	b.WriteString(err.Error())
+	return b.String()
+}
+
+// recoverError converts the panic value to the error.
+func recoverError(r any) error {
+	if err, ok := r.(error); ok {
+		return err
+	}
+	return fmt.Errorf("%v", r)
+}
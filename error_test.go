@@ -0,0 +1,160 @@
+// Copyright 2025 xgfone
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/xgfone/go-validation/validator"
+)
+
+func TestTryValidate(t *testing.T) {
+	if err := TryValidate(1, "min(1)"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	err := TryValidate(0, "min(1)")
+	if err == nil {
+		t.Errorf("expect an error, but got nil")
+	} else if errors.Is(err, ErrInvalidRule) {
+		t.Errorf("unexpect the error ErrInvalidRule: %v", err)
+	}
+
+	tests := map[string]string{
+		"min(1":         "invalid rule 'min(1': 1:6: expected ')', but got 'EOF'",
+		"exp(1, 0, 1)":  "invalid rule 'exp(1, 0, 1)': 1:1: the exp base must not be less than 2",
+		`regexp("a(b")`: "invalid rule 'regexp(\"a(b\")': 1:1: regexp: Compile(`^a(b$`): error parsing regexp: missing closing ): `^a(b$`",
+	}
+	for rule, expect := range tests {
+		err := TryValidate(0, rule)
+		if !errors.Is(err, ErrInvalidRule) {
+			t.Errorf("%s: expect the error ErrInvalidRule, but got %v", rule, err)
+		} else if s := err.Error(); s != expect {
+			t.Errorf("%s: expect error '%s', but got '%s'", rule, expect, s)
+		}
+
+		var rerr *RuleError
+		if !errors.As(err, &rerr) || rerr.Rule != rule {
+			t.Errorf("%s: expect a RuleError, but got %v", rule, err)
+		}
+	}
+}
+
+func TestCompile(t *testing.T) {
+	validators, err := Compile(map[string]string{"Name": "min(1)", "Age": "ranger(1, 120)"})
+	if err != nil {
+		t.Fatal(err)
+	} else if len(validators) != 2 || validators["Age"].String() != "ranger(1, 120)" {
+		t.Errorf("unexpected validators: %v", validators)
+	}
+
+	_, err = Compile(map[string]string{"Name": "min(1)", "Age": "ranger(1)", "Tags": "array(min(1) &&)"})
+	if !errors.Is(err, ErrInvalidRule) {
+		t.Fatalf("expect the error ErrInvalidRule, but got %v", err)
+	}
+
+	errs, ok := err.(validator.Errors)
+	if !ok {
+		t.Fatalf("expect validator.Errors, but got %T", err)
+	}
+
+	expects := []string{
+		"invalid rule 'Age': 1:1: ranger must have and only have two arguments",
+		"invalid rule 'Tags': 1:16: unexpected ')'",
+	}
+	if len(errs) != len(expects) {
+		t.Fatalf("expect %d errors, but got %d: %v", len(expects), len(errs), errs)
+	}
+	for i, err := range errs {
+		if s := err.Error(); s != expects[i] {
+			t.Errorf("%d: expect error '%s', but got '%s'", i, expects[i], s)
+		}
+	}
+}
+
+func TestPosError(t *testing.T) {
+	tests := []struct {
+		rule   string
+		line   int
+		column int
+		token  string
+	}{
+		{"min(1) && max(1, 2)", 1, 11, "max(1, 2)"},
+		{`array(min(1) && regexp("a(b"))`, 1, 17, `regexp("a(b")`},
+		{"min(1) && len > 1.5", 1, 11, "len > 1.5"},
+		{"array(undefined)", 1, 7, "undefined"},
+		{"min(1) &&\n\tmax(-zero)", 2, 6, ""},
+		{"min(1) &&\n\tmax(1, 2)", 2, 2, "max(1, 2)"},
+	}
+
+	for _, test := range tests {
+		_, err := DefaultBuilder.BuildValidator(test.rule)
+
+		var perr *PosError
+		if !errors.As(err, &perr) {
+			t.Errorf("%q: expect a PosError, but got %v", test.rule, err)
+		} else if perr.Line != test.line || perr.Column != test.column || perr.Token != test.token {
+			t.Errorf("%q: expect %d:%d '%s', but got %d:%d '%s'", test.rule,
+				test.line, test.column, test.token, perr.Line, perr.Column, perr.Token)
+		}
+	}
+
+	// The position is relative to the sub-rule referring to the variables.
+	err := ValidateWithVars(1, "min(1) &&\n\tmax($n, 2)", validator.VarMap{"n": 1})
+	expect := "max($n, 2)\n^^^^^^^^^^\ninvalid rule 'max($n, 2)': 1:1: max must have and only have one argument"
+	if s := FormatError("max($n, 2)", err); s != expect {
+		t.Errorf("expect '%s', but got '%s'", expect, s)
+	}
+
+	if s := FormatError("min(1)", errors.New("test")); s != "test" {
+		t.Errorf("expect 'test', but got '%s'", s)
+	}
+}
+
+func ExampleFormatError() {
+	const rule = "min(1) && max(1, 2)"
+	if _, err := DefaultBuilder.BuildValidator(rule); err != nil {
+		fmt.Println(FormatError(rule, err))
+	}
+
+	// Output:
+	// min(1) && max(1, 2)
+	//           ^^^^^^^^^
+	// 1:11: max must have and only have one argument
+}
+
+func TestValidateValidationError(t *testing.T) {
+	var ve *validator.ValidationError
+	err := Validate("ab", "min(3)")
+	if !errors.As(err, &ve) {
+		t.Fatalf("expect a ValidationError, but got %T", err)
+	}
+	if ve.Path != "" || ve.Rule != "min(3)" || ve.Value != "ab" {
+		t.Errorf("unexpected ValidationError: %+v", *ve)
+	}
+	if s := err.Error(); s != "the string length is less than 3" {
+		t.Errorf("unexpected error: %s", s)
+	}
+
+	err = TryValidate([]string{"abc", "a"}, "array(min(3))")
+	if !errors.As(err, &ve) {
+		t.Fatalf("expect a ValidationError, but got %T", err)
+	}
+	if ve.Path != "[1]" || ve.Rule != "min(3)" || ve.Value != "a" {
+		t.Errorf("unexpected ValidationError: %+v", *ve)
+	}
+}
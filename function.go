@@ -15,24 +15,38 @@
 package validation
 
 import (
+	"errors"
 	"fmt"
+	"reflect"
+	"strings"
+	"time"
 
 	"github.com/xgfone/go-validation/validator"
 	"github.com/xgfone/predicate"
 )
 
 // Function is called by the builder to parse and build the validator.
+//
+// The function may describe itself by implementing the interface
+// FunctionDescriber, which is used by the catalog of the builder.
 type Function interface {
 	Call(c *Context, args ...any) error
 	Name() string
 }
 
+// FunctionDescriber is an optional interface implemented by Function
+// to describe its signature, summary and examples.
+type FunctionDescriber interface {
+	Info() FuncInfo
+}
+
 type functionImpl struct {
 	call func(*Context, ...any) error
-	name string
+	info FuncInfo
 }
 
-func (f functionImpl) Name() string { return f.name }
+func (f functionImpl) Info() FuncInfo { return f.info.clone() }
+func (f functionImpl) Name() string   { return f.info.Name }
 func (f functionImpl) Call(c *Context, args ...any) error {
 	return f.call(c, args...)
 }
@@ -43,9 +57,138 @@ func toBuilderFunction(f Function) predicate.BuilderFunction {
 	}
 }
 
-// NewFunction returns a new Function.
+// NewFunction returns a new Function, the parameters of which are unknown.
 func NewFunction(name string, call func(*Context, ...any) error) Function {
-	return functionImpl{name: name, call: call}
+	return functionImpl{info: FuncInfo{Name: name}, call: call}
+}
+
+// NewFunctionWithInfo returns a new Function described by the info.
+func NewFunctionWithInfo(info FuncInfo, call func(*Context, ...any) error) Function {
+	return functionImpl{info: info.clone(), call: call}
+}
+
+// newFunction returns a new Function with the parameters,
+// which rejects the keyword arguments if no keyword parameters.
+func newFunction(name string, params []Param, call func(*Context, ...any) error) Function {
+	for _, p := range params {
+		if p.Keyword {
+			return functionImpl{info: FuncInfo{Name: name, Params: params}, call: call}
+		}
+	}
+
+	return functionImpl{info: FuncInfo{Name: name, Params: params}, call: func(c *Context, args ...any) error {
+		for _, arg := range args {
+			if kw, ok := arg.(KeywordArg); ok {
+				return fmt.Errorf("%s does not support the keyword argument '%s'", name, kw.Name)
+			}
+		}
+		return call(c, args...)
+	}}
+}
+
+// KeywordArg is the keyword argument of the function call in the rule,
+// such as `loc="UTC"` in `time("2006-01-02", loc="UTC")`, which is passed
+// to the function after all the positional arguments.
+type KeywordArg struct {
+	Name  string
+	Value any
+}
+
+// SplitKeywordArgs splits the arguments into the positional arguments
+// and the keyword arguments.
+func SplitKeywordArgs(args []any) (positional []any, kwargs map[string]any) {
+	for _, arg := range args {
+		if kw, ok := arg.(KeywordArg); ok {
+			if kwargs == nil {
+				kwargs = make(map[string]any)
+			}
+			kwargs[kw.Name] = kw.Value
+		} else {
+			positional = append(positional, arg)
+		}
+	}
+	return
+}
+
+// expandLists expands the list arguments, such as
+// ["a", "b"], "c" => "a", "b", "c".
+func expandLists(args []any) []any {
+	expanded := make([]any, 0, len(args))
+	for _, arg := range args {
+		if list, ok := arg.([]any); ok {
+			expanded = append(expanded, list...)
+		} else {
+			expanded = append(expanded, arg)
+		}
+	}
+	return expanded
+}
+
+// GetFuncInfo returns the information of the function.
+//
+// If the function does not implement the interface FunctionDescriber,
+// only the name is set.
+func GetFuncInfo(f Function) FuncInfo {
+	if d, ok := f.(FunctionDescriber); ok {
+		info := d.Info()
+		info.Name = f.Name()
+		return info
+	}
+	return FuncInfo{Name: f.Name()}
+}
+
+// Describe returns a new Function with the summary and examples,
+// which keeps the parameters of the original function.
+//
+// Example:
+//
+//	Describe(NewFunctionWithOneFloat("min", validators.Min),
+//		"the value or length is not less than the argument", "min(1)")
+func Describe(f Function, summary string, examples ...string) Function {
+	info := GetFuncInfo(f)
+	info.Summary = summary
+	info.Examples = examples
+	return NewFunctionWithInfo(info, f.Call)
+}
+
+// NameParams returns a new Function with the names of the parameters,
+// which is ignored if the parameter is unknown.
+//
+// Example:
+//
+//	NameParams(NewFunctionWithTwoFloats("ranger", validators.Ranger), "min", "max")
+func NameParams(f Function, names ...string) Function {
+	info := GetFuncInfo(f)
+	for i := range info.Params {
+		if i < len(names) {
+			info.Params[i].Name = names[i]
+		}
+	}
+	return NewFunctionWithInfo(info, f.Call)
+}
+
+// WithCost returns a new Function with the cost hint of the validator,
+// which is used by the optimizer. See FuncInfo.Cost and Builder.Optimize.
+//
+// Example:
+//
+//	WithCost(NewFunctionWithOneString("regexp", validators.Regexp), 50)
+func WithCost(f Function, cost int) Function {
+	info := GetFuncInfo(f)
+	info.Cost = cost
+	return NewFunctionWithInfo(info, f.Call)
+}
+
+// WithTypes returns a new Function with the types of the values supported
+// by the validator, which is used by Lint. See FuncInfo.Types.
+//
+// Example:
+//
+//	WithTypes(NewFunctionWithoutArgs("isinteger", validators.IsInteger), ValueString)
+func WithTypes(f Function, types ...ValueType) Function {
+	info := GetFuncInfo(f)
+	info.Types = append([]ValueType(nil), types...)
+	return NewFunctionWithInfo(info, f.Call)
 }
 
 // ValidatorFunction converts a validator to a Function with the name,
@@ -61,7 +204,7 @@ func ValidatorFunction(name string, v validator.Validator) Function {
 // NewFunctionWithoutArgs returns a new Function which parses and builds
 // the validator without any arguments.
 func NewFunctionWithoutArgs(name string, newf func() validator.Validator) Function {
-	return NewFunction(name, func(c *Context, args ...any) (err error) {
+	return newFunction(name, []Param{}, func(c *Context, args ...any) (err error) {
 		if len(args) > 0 {
 			err = fmt.Errorf("%s must not have any arguments", name)
 		} else {
@@ -74,7 +217,7 @@ func NewFunctionWithoutArgs(name string, newf func() validator.Validator) Functi
 // NewFunctionWithOneFloat returns a new Function which parses and builds
 // the validator with only one float64 argument.
 func NewFunctionWithOneFloat(name string, newf func(float64) validator.Validator) Function {
-	return NewFunction(name, func(c *Context, args ...any) (err error) {
+	return newFunction(name, []Param{{Type: ParamFloat}}, func(c *Context, args ...any) (err error) {
 		if len(args) != 1 {
 			return fmt.Errorf("%s must have and only have one argument", name)
 		}
@@ -109,7 +252,7 @@ func getFloat(name string, index int, i any) (f float64, err error) {
 // NewFunctionWithTwoFloats returns a new Function which parses and builds
 // the validator with only two float64 arguments.
 func NewFunctionWithTwoFloats(name string, newf func(float64, float64) validator.Validator) Function {
-	return NewFunction(name, func(c *Context, args ...any) (err error) {
+	return newFunction(name, []Param{{Type: ParamFloat}, {Type: ParamFloat}}, func(c *Context, args ...any) (err error) {
 		if len(args) != 2 {
 			return fmt.Errorf("%s must have and only have two arguments", name)
 		}
@@ -130,9 +273,11 @@ func NewFunctionWithTwoFloats(name string, newf func(float64, float64) validator
 }
 
 // NewFunctionWithFloats returns a new Function which parses and builds
-// the validator with any float64 arguments.
+// the validator with any float64 arguments, which may be given by a list,
+// such as "f(1, 2)" or "f([1, 2])".
 func NewFunctionWithFloats(name string, newf func(...float64) validator.Validator) Function {
-	return NewFunction(name, func(c *Context, args ...any) (err error) {
+	return newFunction(name, []Param{{Type: ParamFloat, Variadic: true}}, func(c *Context, args ...any) (err error) {
+		args = expandLists(args)
 		vs := make([]float64, len(args))
 		for i, v := range args {
 			if vs[i], err = getFloat(name, i, v); err != nil {
@@ -147,7 +292,7 @@ func NewFunctionWithFloats(name string, newf func(...float64) validator.Validato
 // NewFunctionWithOneString returns a new Function which parses and builds
 // the validator with only one string argument.
 func NewFunctionWithOneString(name string, newf func(string) validator.Validator) Function {
-	return NewFunction(name, func(c *Context, args ...any) (err error) {
+	return newFunction(name, []Param{{Type: ParamString}}, func(c *Context, args ...any) (err error) {
 		if len(args) != 1 {
 			return fmt.Errorf("%s must have and only have one argument", name)
 		}
@@ -163,10 +308,12 @@ func NewFunctionWithOneString(name string, newf func(string) validator.Validator
 }
 
 // NewFunctionWithStrings returns a new Function which parses and builds
-// the validator with any string arguments.
+// the validator with any string arguments, which may be given by a list,
+// such as `oneof("a", "b")` or `oneof(["a", "b"])`.
 func NewFunctionWithStrings(name string, newf func(...string) validator.Validator) Function {
-	return NewFunction(name, func(c *Context, args ...any) (err error) {
+	return newFunction(name, []Param{{Type: ParamString, Variadic: true}}, func(c *Context, args ...any) (err error) {
 		var ok bool
+		args = expandLists(args)
 		vs := make([]string, len(args))
 		for i, v := range args {
 			if vs[i], ok = v.(string); !ok {
@@ -183,7 +330,7 @@ func NewFunctionWithStrings(name string, newf func(...string) validator.Validato
 //
 // Notice: the parsed validators is composed to a new Valiator by And.
 func NewFunctionWithValidators(name string, newf func(...validator.Validator) validator.Validator) Function {
-	return NewFunction(name, func(c *Context, args ...any) (err error) {
+	return newFunction(name, []Param{{Type: ParamValidator, Variadic: true}}, func(c *Context, args ...any) (err error) {
 		if len(args) == 0 {
 			return fmt.Errorf("%s validator has no arguments", name)
 		}
@@ -210,7 +357,7 @@ func NewFunctionWithValidators(name string, newf func(...validator.Validator) va
 // NewFunctionWithThreeInts returns a new Function which parses and builds
 // the validator with only three int arguments.
 func NewFunctionWithThreeInts(name string, newf func(int, int, int) validator.Validator) Function {
-	return NewFunction(name, func(c *Context, args ...any) (err error) {
+	return newFunction(name, []Param{{Type: ParamInt}, {Type: ParamInt}, {Type: ParamInt}}, func(c *Context, args ...any) (err error) {
 		if len(args) != 3 {
 			return fmt.Errorf("%s must have and only have three arguments", name)
 		}
@@ -249,3 +396,313 @@ func getInt(name string, index int, i any) (v int, err error) {
 
 	return
 }
+
+// ************************************************************************* //
+
+var (
+	validatorType = reflect.TypeOf((*validator.Validator)(nil)).Elem()
+	durationType  = reflect.TypeOf(time.Duration(0))
+	errorType     = reflect.TypeOf((*error)(nil)).Elem()
+)
+
+// NewFunctionFrom returns a new Function which parses and builds
+// the validator by calling the constructor ctor by reflection,
+// which converts the arguments of the rule to the parameter types of ctor.
+//
+// ctor must be a function returning validator.Validator, or returning
+// (validator.Validator, error), and the types of its parameters
+// are one of the types as follow:
+//
+//	int, int8, int16, int32, int64
+//	uint, uint8, uint16, uint32, uint64
+//	float32, float64
+//	string
+//	bool: true or false
+//	time.Duration: a duration, such as 1s, or a duration string, such as "1s"
+//	validator.Validator: a sub-rule, such as min(1) && max(10), or true/false
+//	[]T: a list, such as ["a", "b"], the element type T of which is one of above
+//
+// And the last parameter may be variadic, such as
+//
+//	NewFunctionFrom("lenbetween", func(min, max int, vs ...validator.Validator) validator.Validator {
+//		return validator.And(append([]validator.Validator{validators.Len(">=", min),
+//			validators.Len("<=", max)}, vs...)...)
+//	})
+//
+// The list arguments of the variadic parameter are expanded, if its element
+// type is not a slice, such as f(["a", "b"], "c") => f("a", "b", "c").
+//
+// Or, the last parameter may be a struct to receive the keyword arguments,
+// each exported field of which is a keyword parameter named by the tag
+// "kwarg" or the lower-case field name, and the field is ignored if the tag
+// is "-". The field keeps the zero value if the keyword argument is omitted.
+// For example,
+//
+//	NewFunctionFrom("time", func(layout string, opts struct {
+//		Loc string `kwarg:"loc"`
+//	}) (validator.Validator, error) {
+//		if opts.Loc == "" {
+//			return validators.Time(layout), nil
+//		}
+//
+//		loc, err := time.LoadLocation(opts.Loc)
+//		if err != nil {
+//			return nil, err
+//		}
+//		return validators.TimeIn(layout, loc), nil
+//	})
+//
+// If ctor is invalid, panic.
+func NewFunctionFrom(name string, ctor any) Function {
+	f := reflect.ValueOf(ctor)
+	t := f.Type()
+	if t.Kind() != reflect.Func {
+		panic(fmt.Errorf("%s: the constructor is not a function, but got %T", name, ctor))
+	}
+
+	switch {
+	case t.NumOut() == 1 && t.Out(0) == validatorType:
+	case t.NumOut() == 2 && t.Out(0) == validatorType && t.Out(1) == errorType:
+	default:
+		panic(fmt.Errorf("%s: the constructor must return validator.Validator or (validator.Validator, error)", name))
+	}
+
+	var kwargsType reflect.Type
+	nin := t.NumIn()
+	if !t.IsVariadic() && nin > 0 && t.In(nin-1).Kind() == reflect.Struct {
+		kwargsType = t.In(nin - 1)
+		nin--
+	}
+
+	params := make([]Param, nin, t.NumIn())
+	for i := range params {
+		pt := t.In(i)
+		if t.IsVariadic() && i == nin-1 {
+			pt = pt.Elem()
+			params[i].Variadic = true
+		}
+
+		var ok bool
+		if params[i].Type, ok = getParamType(pt); !ok {
+			panic(fmt.Errorf("%s: unsupported the type '%s' of the %dth parameter", name, pt, i))
+		}
+	}
+
+	kwfields := make(map[string]int) // The field index by the keyword name.
+	if kwargsType != nil {
+		for i := 0; i < kwargsType.NumField(); i++ {
+			field := kwargsType.Field(i)
+			kwname := field.Tag.Get("kwarg")
+			if !field.IsExported() || kwname == "-" {
+				continue
+			} else if kwname == "" {
+				kwname = strings.ToLower(field.Name)
+			}
+
+			ptype, ok := getParamType(field.Type)
+			if !ok {
+				panic(fmt.Errorf("%s: unsupported the type '%s' of the keyword parameter '%s'", name, field.Type, kwname))
+			}
+
+			kwfields[kwname] = i
+			params = append(params, Param{Name: kwname, Type: ptype, Keyword: true})
+		}
+	}
+
+	return newFunction(name, params, func(c *Context, args ...any) (err error) {
+		for _, arg := range args {
+			if kw, ok := arg.(KeywordArg); ok {
+				if _, ok = kwfields[kw.Name]; !ok {
+					return fmt.Errorf("%s does not support the keyword argument '%s'", name, kw.Name)
+				}
+			}
+		}
+
+		args, kwargs := SplitKeywordArgs(args)
+		if t.IsVariadic() {
+			if len(args) < nin-1 {
+				return fmt.Errorf("%s expects at least %d arguments, but got %d", name, nin-1, len(args))
+			}
+
+			// Expand the list arguments of the variadic parameter,
+			// like NewFunctionWithStrings, such as f(["a", "b"], "c").
+			if t.In(nin-1).Elem().Kind() != reflect.Slice {
+				args = append(args[:nin-1:nin-1], expandLists(args[nin-1:])...)
+			}
+		} else if len(args) != nin {
+			return fmt.Errorf("%s expects %d arguments, but got %d", name, nin, len(args))
+		}
+
+		in := make([]reflect.Value, len(args), len(args)+1)
+		for i, arg := range args {
+			var pt reflect.Type
+			if t.IsVariadic() && i >= nin-1 {
+				pt = t.In(nin - 1).Elem()
+			} else {
+				pt = t.In(i)
+			}
+
+			if in[i], err = convertArg(c, pt, arg); err != nil {
+				return fmt.Errorf("%s: invalid %dth argument: %w", name, i, err)
+			}
+		}
+
+		if kwargsType != nil {
+			opts := reflect.New(kwargsType).Elem()
+			for kwname, arg := range kwargs {
+				field := opts.Field(kwfields[kwname])
+				v, err := convertArg(c, field.Type(), arg)
+				if err != nil {
+					return fmt.Errorf("%s: invalid keyword argument '%s': %w", name, kwname, err)
+				}
+				field.Set(v)
+			}
+			in = append(in, opts)
+		}
+
+		out := f.Call(in)
+		if len(out) == 2 && !out[1].IsNil() {
+			return out[1].Interface().(error)
+		}
+		c.AppendValidators(out[0].Interface().(validator.Validator))
+		return
+	})
+}
+
+var errFalseRule = errors.New("the value is not allowed")
+
+// boolValidator returns the validator of the bool literal used as a sub-rule,
+// such as true in "when(zero, true, min(8))", which always passes or fails.
+func boolValidator(b bool) validator.Validator {
+	if b {
+		return validator.NewValidator("true", func(any) error { return nil })
+	}
+	return validator.NewValidator("false", func(any) error { return errFalseRule })
+}
+
+func getParamType(t reflect.Type) (ParamType, bool) {
+	switch t {
+	case validatorType:
+		return ParamValidator, true
+	case durationType:
+		return ParamDuration, true
+	}
+
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return ParamInt, true
+	case reflect.Float32, reflect.Float64:
+		return ParamFloat, true
+	case reflect.String:
+		return ParamString, true
+	case reflect.Bool:
+		return ParamBool, true
+	case reflect.Slice:
+		if et, ok := getParamType(t.Elem()); ok {
+			return "[]" + et, true
+		}
+		return "", false
+	default:
+		return "", false
+	}
+}
+
+func convertArg(c *Context, t reflect.Type, arg any) (v reflect.Value, err error) {
+	switch t {
+	case validatorType:
+		if b, ok := arg.(bool); ok {
+			return reflect.ValueOf(boolValidator(b)), nil
+		}
+
+		b, ok := arg.(predicate.ContextBuilder)
+		if !ok {
+			return v, fmt.Errorf("expect a validator, but got %T", arg)
+		}
+
+		nc := c.New().(*Context)
+		if err = b.Build(nc); err != nil {
+			return
+		}
+		return reflect.ValueOf(nc.Validator()), nil
+
+	case durationType:
+		switch a := arg.(type) {
+		case time.Duration:
+			return reflect.ValueOf(a), nil
+		case string:
+			d, err := time.ParseDuration(a)
+			if err != nil {
+				return v, err
+			}
+			return reflect.ValueOf(d), nil
+		default:
+			return v, fmt.Errorf("expect a duration, but got %T", arg)
+		}
+	}
+
+	v = reflect.New(t).Elem()
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, ok := arg.(int)
+		if !ok {
+			return v, fmt.Errorf("expect an integer, but got %T", arg)
+		}
+		if v.OverflowInt(int64(i)) {
+			return v, fmt.Errorf("%d overflows %s", i, t)
+		}
+		v.SetInt(int64(i))
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		i, ok := arg.(int)
+		if !ok {
+			return v, fmt.Errorf("expect an integer, but got %T", arg)
+		}
+		if i < 0 || v.OverflowUint(uint64(i)) {
+			return v, fmt.Errorf("%d overflows %s", i, t)
+		}
+		v.SetUint(uint64(i))
+
+	case reflect.Float32, reflect.Float64:
+		f, err := getFloat("", 0, arg)
+		if err != nil {
+			return v, fmt.Errorf("expect a number, but got %T", arg)
+		}
+		v.SetFloat(f)
+
+	case reflect.String:
+		s, ok := arg.(string)
+		if !ok {
+			return v, fmt.Errorf("expect a string, but got %T", arg)
+		}
+		v.SetString(s)
+
+	case reflect.Bool:
+		b, ok := arg.(bool)
+		if !ok {
+			return v, fmt.Errorf("expect a bool, but got %T", arg)
+		}
+		v.SetBool(b)
+
+	case reflect.Slice:
+		if reflect.TypeOf(arg) == t {
+			return reflect.ValueOf(arg), nil
+		}
+
+		list, ok := arg.([]any)
+		if !ok {
+			return v, fmt.Errorf("expect a list, but got %T", arg)
+		}
+
+		v = reflect.MakeSlice(t, len(list), len(list))
+		for i, e := range list {
+			ev, err := convertArg(c, t.Elem(), e)
+			if err != nil {
+				return v, fmt.Errorf("the %dth element: %w", i, err)
+			}
+			v.Index(i).Set(ev)
+		}
+	}
+
+	return
+}
@@ -0,0 +1,190 @@
+// Copyright 2025 xgfone
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/xgfone/go-validation/validator"
+	"github.com/xgfone/go-validation/validator/validators"
+)
+
+func ExampleNewFunctionFrom() {
+	builder := NewBuilder()
+	RegisterDefaultsForBuilder(builder)
+	builder.RegisterFunction(NewFunctionFrom("lenbetween", func(min, max int, vs ...validator.Validator) validator.Validator {
+		return validator.And(append([]validator.Validator{validators.Len(">=", min),
+			validators.Len("<=", max)}, vs...)...)
+	}))
+
+	fmt.Println(builder.Validate("abc", `lenbetween(2, 4)`))
+	fmt.Println(builder.Validate("abcde", `lenbetween(2, 4)`))
+	fmt.Println(builder.Validate("abc", `lenbetween(2, 4, oneof("a", "ab"))`))
+
+	// Output:
+	// <nil>
+	// the length must be less than or equal to 4
+	// the string 'abc' is not one of [a ab]
+}
+
+func TestNewFunctionFrom(t *testing.T) {
+	var got []any
+	builder := NewBuilder()
+	RegisterDefaultsForBuilder(builder)
+	builder.RegisterSymbol("enabled", true)
+	builder.RegisterSymbol("names", []string{"a", "b"})
+
+	builder.RegisterFunction(NewFunctionFrom("test", func(s string, i int8, u uint, f float32, b bool,
+		d time.Duration, ss []string, vs ...validator.Validator) validator.Validator {
+		got = []any{s, i, u, f, b, d, ss, len(vs)}
+		return validator.NewValidator("test", func(any) error { return nil })
+	}))
+
+	builder.RegisterFunction(NewFunctionFrom("fail", func(n int) (validator.Validator, error) {
+		return nil, errors.New("fail to build")
+	}))
+
+	rule := `test("abc", 1, 2, 3, enabled, "1m", names, min(1), max(2) && zero)`
+	if err := builder.TryValidate(1, rule); err != nil {
+		t.Fatal(err)
+	}
+
+	expect := []any{"abc", int8(1), uint(2), float32(3), true, time.Minute, []string{"a", "b"}, 2}
+	if fmt.Sprint(got) != fmt.Sprint(expect) {
+		t.Errorf("expect arguments %v, but got %v", expect, got)
+	}
+
+	info, _ := builder.Catalog().Lookup("test")
+	if s := info.Signature(); s != "test(string, int, int, float, bool, duration, []string, ...validator)" {
+		t.Errorf("unexpected signature '%s'", s)
+	}
+
+	errs := map[string]string{
+		`test("abc", 1, 2, 3, enabled, "1m")`:                   "test expects at least 7 arguments, but got 6",
+		`test("abc", 128, 2, 3, enabled, "1m", names)`:          "test: invalid 1th argument: 128 overflows int8",
+		`test("abc", 1, -2, 3, enabled, "1m", names)`:           "test: invalid 2th argument: -2 overflows uint",
+		`test("abc", 1, 2, "3", enabled, "1m", names)`:          "test: invalid 3th argument: expect a number, but got string",
+		`test("abc", 1, 2, 3, 1, "1m", names)`:                  "test: invalid 4th argument: expect a bool, but got int",
+		`test("abc", 1, 2, 3, enabled, "1x", names)`:            `test: invalid 5th argument: time: unknown unit "x" in duration "1x"`,
+		`test("abc", 1, 2, 3, enabled, "1m", names, min(1), 2)`: "test: invalid 8th argument: expect a validator, but got int",
+		`fail(1)`:    "fail to build",
+		`fail(1, 2)`: "fail expects 1 arguments, but got 2",
+	}
+	for rule, expect := range errs {
+		err := builder.TryValidate(1, rule)
+		if err == nil {
+			t.Errorf("%s: expect an error, but got nil", rule)
+		} else if s := err.Error(); !strings.HasSuffix(s, expect) {
+			t.Errorf("%s: expect error '%s', but got '%s'", rule, expect, s)
+		}
+	}
+}
+
+func TestNewFunctionFromVariadicList(t *testing.T) {
+	var got []string
+	builder := NewBuilder()
+	builder.RegisterFunction(NewFunctionFrom("from", func(ss ...string) validator.Validator {
+		got = ss
+		return validator.NewValidator("from", func(any) error { return nil })
+	}))
+	builder.RegisterFunction(NewFunctionWithStrings("strings", func(ss ...string) validator.Validator {
+		got = ss
+		return validator.NewValidator("strings", func(any) error { return nil })
+	}))
+
+	for _, args := range []string{`["a", "b"]`, `"a", "b"`, `["a"], "b"`} {
+		for _, name := range []string{"from", "strings"} {
+			got = nil
+			if err := builder.TryValidate(1, name+"("+args+")"); err != nil {
+				t.Errorf("%s(%s): unexpected error: %v", name, args, err)
+			} else if fmt.Sprint(got) != "[a b]" {
+				t.Errorf("%s(%s): expect arguments %v, but got %v", name, args, []string{"a", "b"}, got)
+			}
+		}
+	}
+}
+
+func TestNewFunctionFromPanic(t *testing.T) {
+	for name, ctor := range map[string]any{
+		"notfunc":   1,
+		"noresult":  func() {},
+		"badresult": func() error { return nil },
+		"badparam":  func(map[string]int) validator.Validator { return nil },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s: expect a panic, but got nil", name)
+				}
+			}()
+			NewFunctionFrom(name, ctor)
+		}()
+	}
+}
+
+func TestNewFunctionFromKeywordArgs(t *testing.T) {
+	type options struct {
+		Loc     string        `kwarg:"loc"`
+		Strict  bool          // Named "strict" by default.
+		Timeout time.Duration `kwarg:"timeout"`
+		Ignored int           `kwarg:"-"`
+	}
+
+	var got []any
+	builder := NewBuilder()
+	builder.RegisterFunction(NewFunctionFrom("test", func(ns []int, opts options) validator.Validator {
+		got = []any{ns, opts.Loc, opts.Strict, opts.Timeout}
+		return validator.NewValidator("test", func(any) error { return nil })
+	}))
+
+	info, _ := builder.Catalog().Lookup("test")
+	if s := info.Signature(); s != "test([]int, loc=string, strict=bool, timeout=duration)" {
+		t.Errorf("unexpected signature '%s'", s)
+	}
+
+	tests := map[string][]any{
+		`test([1, 2])`:            {[]int{1, 2}, "", false, time.Duration(0)},
+		`test([], timeout=1m30s)`: {[]int{}, "", false, 90 * time.Second},
+		`test([3], strict=true, loc="UTC", timeout="1s",)`: {[]int{3}, "UTC", true, time.Second},
+	}
+	for rule, expect := range tests {
+		if err := builder.TryValidate(1, rule); err != nil {
+			t.Errorf("%s: unexpected error: %v", rule, err)
+		} else if fmt.Sprint(got) != fmt.Sprint(expect) {
+			t.Errorf("%s: expect arguments %v, but got %v", rule, expect, got)
+		}
+	}
+
+	errs := map[string]string{
+		`test([1], ignored=1)`:   "test does not support the keyword argument 'ignored'",
+		`test([1], strict=1)`:    "test: invalid keyword argument 'strict': expect a bool, but got int",
+		`test([1, "2"])`:         "test: invalid 0th argument: the 1th element: expect an integer, but got string",
+		`test(1)`:                "test: invalid 0th argument: expect a list, but got int",
+		`test(loc="UTC")`:        "test expects 1 arguments, but got 0",
+		`test([1], timeout=1.5)`: "test: invalid keyword argument 'timeout': expect a duration, but got float64",
+	}
+	for rule, expect := range errs {
+		err := builder.TryValidate(1, rule)
+		if err == nil {
+			t.Errorf("%s: expect an error, but got nil", rule)
+		} else if s := err.Error(); !strings.HasSuffix(s, expect) {
+			t.Errorf("%s: expect error '%s', but got '%s'", rule, expect, s)
+		}
+	}
+}
@@ -29,13 +29,7 @@ func NewOneOf(name string, values ...string) OneOf {
 		panic(fmt.Errorf("%s: the values must be empty", name))
 	}
 
-	s, err := encodeJSON(values)
-	if err != nil {
-		panic(err)
-	}
-
-	desc := fmt.Sprintf("%s(%s)", name, s[1:len(s)-1])
-	return OneOf{name: name, desc: desc, values: values}
+	return OneOf{name: name, desc: FormatCall(name, values...), values: values}
 }
 
 // Name returns the name.
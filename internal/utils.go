@@ -15,22 +15,38 @@
 package internal
 
 import (
-	"bytes"
-	"encoding/json"
 	"reflect"
+	"strconv"
+	"strings"
 )
 
-func encodeJSON(value any) (s string, err error) {
-	buf := bytes.NewBuffer(make([]byte, 0, 32))
-	enc := json.NewEncoder(buf)
-	enc.SetEscapeHTML(false)
-	if err = enc.Encode(value); err == nil {
-		s = buf.String()
-		if _len := len(s); _len > 0 && s[_len-1] == '\n' {
-			s = s[:_len-1]
+// FormatFloat formats the float as a literal of the rule,
+// which is parsed back to the same number.
+func FormatFloat(f float64) string {
+	s := strconv.FormatFloat(f, 'f', -1, 64)
+	if strings.IndexByte(s, '.') < 0 {
+		if _, err := strconv.Atoi(s); err != nil { // Too big for an integer literal
+			s = strconv.FormatFloat(f, 'g', -1, 64)
 		}
 	}
-	return
+	return s
+}
+
+// FormatCall formats the function call with the quoted string arguments,
+// such as `name("arg1", "arg2")`.
+func FormatCall(name string, args ...string) string {
+	var b strings.Builder
+	b.Grow(len(name) + 2 + len(args)*8)
+	b.WriteString(name)
+	b.WriteByte('(')
+	for i, arg := range args {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		b.WriteString(strconv.Quote(arg))
+	}
+	b.WriteByte(')')
+	return b.String()
 }
 
 func indirect(value any) any {
@@ -52,3 +68,24 @@ func indirect(value any) any {
 
 // Indirect is exported.
 func Indirect(value any) any { return indirect(value) }
+
+// IsZero reports whether the value is ZERO, which is nil, a nil pointer,
+// a value implementing the method IsZero() returning true, or a zero value.
+func IsZero(v any) bool {
+	if v == nil {
+		return true
+	}
+
+	vf := reflect.ValueOf(v)
+	switch vf.Kind() {
+	case reflect.Ptr, reflect.Interface, reflect.Map, reflect.Slice, reflect.Func, reflect.Chan:
+		if vf.IsNil() {
+			return true
+		}
+	}
+
+	if i, ok := v.(interface{ IsZero() bool }); ok && i.IsZero() {
+		return true
+	}
+	return vf.IsZero()
+}
@@ -0,0 +1,53 @@
+// Copyright 2025 xgfone
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"testing"
+	"time"
+)
+
+type zeroer bool
+
+func (z zeroer) IsZero() bool { return bool(z) }
+
+func TestIsZero(t *testing.T) {
+	var i int
+	for index, v := range []any{nil, 0, "", (*int)(nil), (*time.Time)(nil), []int(nil), time.Time{}, zeroer(true)} {
+		if !IsZero(v) {
+			t.Errorf("%d: expect ZERO, but got %v", index, v)
+		}
+	}
+
+	for index, v := range []any{1, "a", &i, []int{}, time.Now()} {
+		if IsZero(v) {
+			t.Errorf("%d: unexpected ZERO %v", index, v)
+		}
+	}
+}
+
+func TestFormatFloat(t *testing.T) {
+	for f, expect := range map[float64]string{
+		0: "0", 1: "1", -1.5: "-1.5", 0.001: "0.001", 1e21: "1e+21", -1e30: "-1e+30",
+	} {
+		if s := FormatFloat(f); s != expect {
+			t.Errorf("%v: expect '%s', but got '%s'", f, expect, s)
+		}
+	}
+
+	if s := FormatCall("oneof", `a"b`, "c\\d"); s != `oneof("a\"b", "c\\d")` {
+		t.Errorf("unexpected call '%s'", s)
+	}
+}
@@ -0,0 +1,362 @@
+// Copyright 2025 xgfone
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"errors"
+	"fmt"
+	"go/token"
+	"regexp/syntax"
+	"strings"
+)
+
+// ErrRuleLimit is the base error that the rule violates the limits,
+// which is wrapped by the error returned when building the rule.
+var ErrRuleLimit = errors.New("the rule violates the limits")
+
+// Limits is the safety limits of the rules built by the builder,
+// which is used to build the untrusted rules, such as those written
+// by the users in the UI.
+//
+// For the integer limit, 0 means no limit.
+type Limits struct {
+	// MaxRuleLength is the maximum number of the bytes of the rule.
+	MaxRuleLength int
+
+	// MaxDepth is the maximum depth of the syntax tree of the rule,
+	// such as 1 for "zero", 2 for "!zero" and "min(1)", 3 for "array(min(1))".
+	//
+	// The chain of the same logical operator is regarded as one level,
+	// such as 3 for "min(1) && max(10) && !zero".
+	MaxDepth int
+
+	// MaxNodes is the maximum number of the nodes of the syntax tree,
+	// including the operators, functions, identifiers and literals.
+	MaxNodes int
+
+	// MaxArgs is the maximum number of the arguments of a function call,
+	// which counts the elements of the list argument.
+	MaxArgs int
+
+	// MaxRegexpSize is the maximum number of the instructions
+	// of the compiled regular expression passed by the argument
+	// of the type ParamRegexp, such as the pattern of regexp("pattern").
+	MaxRegexpSize int
+
+	// AllowFuncs is the allowlist of the functions used by the rule.
+	// If empty, all the functions are allowed unless denied by DenyFuncs.
+	//
+	// The function is matched by the full name or the base name
+	// in the namespace, such as "str.email" or "email" for "str.email".
+	AllowFuncs []string
+
+	// DenyFuncs is the denylist of the functions used by the rule,
+	// such as "isexistingemail" which looks up the DNS records.
+	//
+	// The function is matched like AllowFuncs, such as "existingemail"
+	// for "str.existingemail" installed by StrPack. And the denylist
+	// also applies to the functions used by the named rules, even if they
+	// are registered before setting the limits or by the parent builder,
+	// and the rules of the struct tags validated by "structure".
+	DenyFuncs []string
+}
+
+func (l Limits) clone() Limits {
+	l.AllowFuncs = append([]string(nil), l.AllowFuncs...)
+	l.DenyFuncs = append([]string(nil), l.DenyFuncs...)
+	return l
+}
+
+// SetLimits sets the safety limits of the rules built by the builder,
+// which returns the error wrapping ErrRuleLimit if the rule violates them.
+//
+// The limits only apply to the builder itself, not the parent, and
+// the named rules registered before setting the limits are not checked.
+func (b *Builder) SetLimits(limits Limits) {
+	limits = limits.clone()
+
+	b.lock.Lock()
+	b.limits = limits
+	b.lock.Unlock()
+	b.changed()
+}
+
+// Limits returns the safety limits of the rules built by the builder.
+func (b *Builder) Limits() Limits {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+	return b.limits.clone()
+}
+
+func (b *Builder) getLimits() Limits {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+	return b.limits
+}
+
+func limitError(format string, args ...any) error {
+	return fmt.Errorf("%w: %s", ErrRuleLimit, fmt.Sprintf(format, args...))
+}
+
+// checkRuleLength checks whether the length of the rule exceeds the limit.
+func (b *Builder) checkRuleLength(rule string) error {
+	if max := b.getLimits().MaxRuleLength; max > 0 && len(rule) > max {
+		return limitError("the rule length %d exceeds %d", len(rule), max)
+	}
+	return nil
+}
+
+// checkLimits checks whether the syntax tree of the rule violates the limits.
+func (b *Builder) checkLimits(node ruleNode) error {
+	limits := b.getLimits()
+	if limits.MaxDepth <= 0 && limits.MaxNodes <= 0 && limits.MaxArgs <= 0 &&
+		limits.MaxRegexpSize <= 0 && len(limits.AllowFuncs) == 0 && len(limits.DenyFuncs) == 0 {
+		return nil
+	}
+
+	checker := limitChecker{builder: b, limits: limits}
+	return checker.check(node, 1)
+}
+
+type limitChecker struct {
+	builder *Builder
+	limits  Limits
+	nodes   int
+}
+
+func (c *limitChecker) check(node ruleNode, depth int) (err error) {
+	defer func() { err = wrapPosError(node, err) }()
+
+	if c.limits.MaxDepth > 0 && depth > c.limits.MaxDepth {
+		return limitError("the depth exceeds %d at %s", c.limits.MaxDepth, node)
+	}
+
+	if c.nodes++; c.limits.MaxNodes > 0 && c.nodes > c.limits.MaxNodes {
+		return limitError("the number of the nodes exceeds %d", c.limits.MaxNodes)
+	}
+
+	var children []ruleNode
+	switch n := node.(type) {
+	case *binaryNode:
+		switch n.op {
+		case token.LAND, token.LOR:
+			children = flattenBinary(nil, n.op, n)
+		default:
+			children = []ruleNode{n.x, n.y}
+		}
+
+	case *notNode:
+		children = []ruleNode{n.x}
+
+	case *listNode:
+		children = n.elems
+
+	case *kwargNode:
+		children = []ruleNode{n.value}
+
+	case *identNode:
+		if c.builder.GetFunc(n.Name()) != nil {
+			err = c.checkFunc(n.Name())
+		}
+
+	case *callNode:
+		if err = c.checkFunc(n.name); err == nil {
+			err = c.checkArgs(n)
+		}
+		children = n.args
+	}
+
+	for i := 0; err == nil && i < len(children); i++ {
+		err = c.check(children[i], depth+1)
+	}
+	return
+}
+
+func (c *limitChecker) checkFunc(name string) error {
+	if len(c.limits.AllowFuncs) > 0 && !matchFuncName(c.limits.AllowFuncs, name) {
+		return limitError("the function %s is not allowed", name)
+	}
+	if len(c.limits.DenyFuncs) > 0 {
+		return c.checkDeniedFunc(name, name, make(map[string]struct{}))
+	}
+	return nil
+}
+
+// checkDeniedFunc checks whether the function name is denied, which walks
+// the functions used by the named rule recursively, so the named rule used
+// by the rule cannot bypass the denylist.
+func (c *limitChecker) checkDeniedFunc(used, name string, seen map[string]struct{}) error {
+	if matchFuncName(c.limits.DenyFuncs, name) {
+		if name == used {
+			return limitError("the function %s is denied", name)
+		}
+		return limitError("the function %s used by the named rule %s is denied", name, used)
+	}
+
+	if _, ok := seen[name]; ok {
+		return nil
+	}
+	seen[name] = struct{}{}
+
+	r := c.builder.lookupRule(name)
+	if r == nil {
+		return nil
+	}
+
+	node, err := parseRule(r.rule)
+	if err != nil { // Unreachable, because the named rule has been compiled.
+		return nil
+	}
+
+	for _, fname := range c.funcNames(nil, node) {
+		if err = c.checkDeniedFunc(used, fname, seen); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// funcNames appends the names of the functions used by the node into names.
+func (c *limitChecker) funcNames(names []string, node ruleNode) []string {
+	switch n := node.(type) {
+	case *binaryNode:
+		names = c.funcNames(c.funcNames(names, n.x), n.y)
+
+	case *notNode:
+		names = c.funcNames(names, n.x)
+
+	case *listNode:
+		for _, elem := range n.elems {
+			names = c.funcNames(names, elem)
+		}
+
+	case *kwargNode:
+		names = c.funcNames(names, n.value)
+
+	case *identNode:
+		if c.builder.GetFunc(n.Name()) != nil {
+			names = append(names, n.Name())
+		}
+
+	case *callNode:
+		names = append(names, n.name)
+		for _, arg := range n.args {
+			names = c.funcNames(names, arg)
+		}
+	}
+	return names
+}
+
+// matchFuncName reports whether the function name matches any of names
+// by the full name or the base name in the namespace.
+func matchFuncName(names []string, name string) bool {
+	if containsString(names, name) {
+		return true
+	}
+	if i := strings.LastIndexByte(name, '.'); i >= 0 {
+		return containsString(names, name[i+1:])
+	}
+	return false
+}
+
+func (c *limitChecker) checkArgs(n *callNode) error {
+	if c.limits.MaxArgs > 0 {
+		var count int
+		for _, arg := range n.args {
+			if list, ok := arg.(*listNode); ok {
+				count += len(list.elems)
+			} else {
+				count++
+			}
+		}
+
+		if count > c.limits.MaxArgs {
+			return limitError("%s has %d arguments more than %d", n.name, count, c.limits.MaxArgs)
+		}
+	}
+
+	if c.limits.MaxRegexpSize > 0 {
+		info, ok := c.builder.lookupFuncInfo(n.name)
+		if !ok {
+			return nil
+		}
+
+		for i, arg := range n.args {
+			lit, ok := arg.(*literalNode)
+			if !ok {
+				continue
+			}
+
+			pattern, ok := lit.value.(string)
+			if !ok || paramTypeAt(info.Params, i) != ParamRegexp {
+				continue
+			}
+
+			if size := regexpSize(pattern); size > c.limits.MaxRegexpSize {
+				return limitError("the regexp of %s has %d instructions more than %d",
+					n.name, size, c.limits.MaxRegexpSize)
+			}
+		}
+	}
+
+	return nil
+}
+
+// flattenBinary flattens the operands of the chain of the binary operator op,
+// such as "a && b && c" => [a, b, c].
+func flattenBinary(nodes []ruleNode, op token.Token, node ruleNode) []ruleNode {
+	if n, ok := node.(*binaryNode); ok && n.op == op {
+		nodes = flattenBinary(nodes, op, n.x)
+		return flattenBinary(nodes, op, n.y)
+	}
+	return append(nodes, node)
+}
+
+// paramTypeAt returns the type of the i-th positional parameter.
+func paramTypeAt(params []Param, i int) ParamType {
+	switch _len := len(params); {
+	case i < _len:
+		return params[i].Type
+	case _len > 0 && params[_len-1].Variadic:
+		return params[_len-1].Type
+	default:
+		return ""
+	}
+}
+
+// regexpSize returns the number of the instructions of the compiled regexp.
+//
+// Return 0 if failing to compile it, which is reported by the function.
+func regexpSize(pattern string) int {
+	re, err := syntax.Parse(pattern, syntax.Perl)
+	if err != nil {
+		return 0
+	}
+
+	prog, err := syntax.Compile(re.Simplify())
+	if err != nil {
+		return 0
+	}
+	return len(prog.Inst)
+}
+
+func containsString(ss []string, s string) bool {
+	for _, _s := range ss {
+		if _s == s {
+			return true
+		}
+	}
+	return false
+}
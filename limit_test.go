@@ -0,0 +1,135 @@
+// Copyright 2025 xgfone
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/xgfone/go-validation/validator"
+)
+
+func TestBuilderLimits(t *testing.T) {
+	b := DefaultBuilder.Extend()
+	b.SetLimits(Limits{
+		MaxRuleLength: 64,
+		MaxDepth:      3,
+		MaxNodes:      8,
+		MaxArgs:       3,
+		MaxRegexpSize: 32,
+		DenyFuncs:     []string{"structure"},
+	})
+
+	if limits := b.Extend().Limits(); limits.MaxDepth != 3 || len(limits.DenyFuncs) != 1 {
+		t.Errorf("expect the child builder to inherit the limits, but got %+v", limits)
+	}
+
+	for _, rule := range []string{
+		"min(1) && max(10)",
+		`regexp("^[a-z]+$")`,
+		"array(min(1))",
+		`oneof("a", "b", "c")`,
+		"zero || max(3)",
+	} {
+		if _, err := b.BuildValidator(rule); err != nil {
+			t.Errorf("%s: unexpected error: %v", rule, err)
+		}
+	}
+
+	tests := []struct {
+		rule string
+		err  string
+	}{
+		{strings.Repeat("zero || ", 8) + "zero", "the rule length 68 exceeds 64"},
+		{"min(1) && array(array(!zero))", "1:23: the depth exceeds 3 at !zero"},
+		{"min(1) && max(2) && min(3) && max(4) && zero", "1:35: the number of the nodes exceeds 8"},
+		{`oneof(["a", "b"], "c", "d")`, "1:1: oneof has 4 arguments more than 3"},
+		{`regexp("^[a-z]{40}$")`, "1:1: the regexp of regexp has 44 instructions more than 32"},
+		{"structure", "1:1: the function structure is denied"},
+		{"array(structure)", "1:7: the function structure is denied"},
+	}
+
+	for _, test := range tests {
+		_, err := b.BuildValidator(test.rule)
+		if !errors.Is(err, ErrRuleLimit) {
+			t.Errorf("%s: expect ErrRuleLimit, but got %v", test.rule, err)
+		} else if msg := strings.Replace(err.Error(), ErrRuleLimit.Error()+": ", "", 1); msg != test.err {
+			t.Errorf("%s: expect the error '%s', but got '%s'", test.rule, test.err, msg)
+		}
+	}
+
+	b.SetLimits(Limits{AllowFuncs: []string{"min", "max"}})
+	if _, err := b.BuildValidator("min(1) && max == 3"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if _, err := b.BuildValidator("min(1) && zero"); !errors.Is(err, ErrRuleLimit) {
+		t.Errorf("expect ErrRuleLimit, but got %v", err)
+	}
+
+	// The variables are checked when validating.
+	b.SetLimits(Limits{MaxRegexpSize: 32})
+	err := b.ValidateWithVars("abc", "regexp($pattern)", validator.VarMap{"pattern": "^[a-z]{50}$"})
+	if !errors.Is(err, ErrRuleLimit) {
+		t.Errorf("expect ErrRuleLimit, but got %v", err)
+	}
+}
+
+func TestBuilderDenyFuncsBypass(t *testing.T) {
+	parent := DefaultBuilder.Extend()
+	RegisterStringValidatorsForBuilder(parent)
+	if err := parent.RegisterRule("mail", "isexistingemail"); err != nil {
+		t.Fatal(err)
+	}
+
+	b := parent.Extend()
+	b.Install(StrPack)
+	b.Namespace("mail").RegisterValidatorFunc("isexistingemail", func(any) error { return nil })
+	if err := b.RegisterRule("email2", "max(254) && mail"); err != nil {
+		t.Fatal(err)
+	}
+	b.SetLimits(Limits{DenyFuncs: []string{"isexistingemail", "existingemail"}})
+
+	tests := []struct {
+		rule string
+		err  string
+	}{
+		{"isexistingemail", "1:1: the function isexistingemail is denied"},
+		{"str.existingemail", "1:1: the function str.existingemail is denied"},
+		{"mail.isexistingemail", "1:1: the function mail.isexistingemail is denied"},
+		{"mail", "1:1: the function isexistingemail used by the named rule mail is denied"},
+		{"array(email2)", "1:7: the function isexistingemail used by the named rule email2 is denied"},
+	}
+
+	for _, test := range tests {
+		_, err := b.BuildValidator(test.rule)
+		if !errors.Is(err, ErrRuleLimit) {
+			t.Errorf("%s: expect ErrRuleLimit, but got %v", test.rule, err)
+		} else if msg := strings.Replace(err.Error(), ErrRuleLimit.Error()+": ", "", 1); msg != test.err {
+			t.Errorf("%s: expect the error '%s', but got '%s'", test.rule, test.err, msg)
+		}
+	}
+
+	// The rules of the struct tags are built by the builder with the limits.
+	type User struct {
+		Email string `validate:"isexistingemail"`
+	}
+	if err := b.ValidateStruct(User{Email: "a@b.c"}); !errors.Is(err, ErrRuleLimit) {
+		t.Errorf("expect ErrRuleLimit, but got %v", err)
+	}
+	if err := b.TryValidate([]User{{Email: "a@b.c"}}, "array(structure)"); !errors.Is(err, ErrRuleLimit) {
+		t.Errorf("expect ErrRuleLimit, but got %v", err)
+	}
+}
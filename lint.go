@@ -0,0 +1,500 @@
+// Copyright 2025 xgfone
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"fmt"
+	"go/token"
+	"math"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/xgfone/predicate"
+)
+
+// LintWarning is the warning of the suspicious part of the rule reported by Lint.
+type LintWarning struct {
+	Offset  int    // The byte offset in the rule, starting at 0.
+	Line    int    // The line number, starting at 1.
+	Column  int    // The column number in bytes, starting at 1.
+	Token   string // The suspicious node of the rule.
+	Message string
+}
+
+// String returns the string representation of the warning,
+// such as "1:11: max(5) contradicts min(10), so the rule never passes".
+func (w LintWarning) String() string {
+	return fmt.Sprintf("%d:%d: %s", w.Line, w.Column, w.Message)
+}
+
+// Lint checks the rule statically for the value of the type valueType,
+// and returns the warnings of the rule which is valid but suspicious:
+//
+//   - The validator does not support the type, such as "within(1h)" for string.
+//     See FuncInfo.Types.
+//   - min, max or ranger compares the length of the string checked as a number,
+//     such as "isinteger && min(1)".
+//   - The operands of AND contradict each other, such as "min(10) && max(5)"
+//     and "zero && required", so the rule never passes.
+//   - The branch of OR is unreachable, such as "min(5)" in "min(1) || min(5)",
+//     because the values it accepts have been accepted by the earlier branches.
+//   - The identifier shadows the function, symbol or built-in operand
+//     of the same name, such as the symbol named "len".
+//
+// valueType is dereferenced if it is a pointer. If it is nil or an interface,
+// the type compatibility is not checked.
+//
+// If the rule is invalid, return the error same as Build.
+func (b *Builder) Lint(rule string, valueType reflect.Type) ([]LintWarning, error) {
+	if err := b.checkRuleLength(rule); err != nil {
+		return nil, err
+	}
+
+	node, err := parseRule(rule)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = b.buildRoot(NewContext(), rule, node); err != nil {
+		return nil, err
+	}
+
+	l := linter{builder: b, rule: rule}
+	l.lint(node, valueType)
+	return l.warnings, nil
+}
+
+var (
+	// zeroFuncs and notZeroFuncs are complementary to each other.
+	zeroFuncs    = []string{"zero", "empty"}
+	notZeroFuncs = []string{"notzero", "notempty", "required"}
+
+	// numericStringFuncs checks whether the string is a number.
+	numericStringFuncs = []string{"isnumber", "isinteger", "isint", "isfloat", "str.int", "str.float"}
+
+	// lengthFuncs compares the length of the string instead of its content.
+	lengthFuncs = []string{"min", "max", "ranger"}
+)
+
+type linter struct {
+	builder  *Builder
+	rule     string
+	warnings []LintWarning
+}
+
+func (l *linter) warnf(node ruleNode, format string, args ...any) {
+	perr := newPosError(node, nil)
+	perr.locate(l.rule)
+	l.warnings = append(l.warnings, LintWarning{
+		Offset:  perr.Offset,
+		Line:    perr.Line,
+		Column:  perr.Column,
+		Token:   perr.Token,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+// lint checks the node validating the value of the type t,
+// which is nil if the type is unknown.
+func (l *linter) lint(node ruleNode, t reflect.Type) {
+	switch n := node.(type) {
+	case *binaryNode:
+		switch n.op {
+		case token.LAND, token.LOR:
+			nodes := flattenBinary(nil, n.op, n)
+			if n.op == token.LAND {
+				l.lintAnd(nodes, t)
+			} else {
+				l.lintOr(nodes)
+			}
+
+			for _, node := range nodes {
+				l.lint(node, t)
+			}
+
+		default:
+			l.lintArg(n.x)
+			l.lintArg(n.y)
+		}
+
+	case *notNode:
+		l.lint(n.x, t)
+
+	case *identNode:
+		l.lintShadow(n)
+		if v, _ := l.builder.lookupIdentifier(n.Name()); v != nil {
+			if _, ok := v.(predicate.BuilderFunction); ok {
+				l.lintType(n, n.Name(), t)
+			}
+		}
+
+	case *callNode:
+		l.lintType(n, n.name, t)
+		info, _ := l.builder.lookupFuncInfo(n.name)
+		for i, arg := range n.args {
+			if paramTypeAt(info.Params, i) == ParamValidator {
+				l.lint(arg, elemType(n.name, t))
+			} else {
+				l.lintArg(arg)
+			}
+		}
+	}
+}
+
+// lintArg checks the argument which is not the validator of the value.
+func (l *linter) lintArg(node ruleNode) {
+	switch n := node.(type) {
+	case *identNode:
+		l.lintShadow(n)
+
+	case *listNode:
+		for _, elem := range n.elems {
+			l.lintArg(elem)
+		}
+
+	case *kwargNode:
+		l.lintArg(n.value)
+
+	case *literalNode, *varNode:
+
+	default: // The sub-rule of the function with the unknown parameters.
+		l.lint(node, nil)
+	}
+}
+
+// lintType checks whether the validator built by the function supports the type t.
+func (l *linter) lintType(node ruleNode, name string, t reflect.Type) {
+	info, ok := l.builder.lookupFuncInfo(name)
+	if !ok || info.Types == nil {
+		return
+	}
+
+	types, ok := valueTypesOf(t)
+	if !ok {
+		return
+	}
+
+	for _, _type := range types {
+		for _, supported := range info.Types {
+			if _type == supported {
+				return
+			}
+		}
+	}
+	l.warnf(node, "%s does not support the type %s", name, t)
+}
+
+// lintShadow checks whether the identifier shadows others of the same name,
+// which are the functions and symbols of the builder and its parents,
+// and the built-in operands.
+func (l *linter) lintShadow(n *identNode) {
+	var kinds []string
+	add := func(kind string) {
+		if !containsString(kinds, kind) {
+			kinds = append(kinds, kind)
+		}
+	}
+
+	name := n.Name()
+	for b := l.builder; b != nil; b = b.parent {
+		b.lock.RLock()
+		_, isFunc := b.funcs[name]
+		_, isSymbol := b.symbol(name)
+		b.lock.RUnlock()
+
+		if isFunc {
+			add("function")
+		}
+		if isSymbol {
+			add("symbol")
+		}
+	}
+	if _, ok := operands[name]; ok {
+		add("built-in operand")
+	}
+
+	if len(kinds) > 1 {
+		l.warnf(n, "%s refers to the %s, which shadows the %s", name, kinds[0], strings.Join(kinds[1:], " and the "))
+	}
+}
+
+// lintAnd checks the operands of the chain of AND.
+func (l *linter) lintAnd(nodes []ruleNode, t reflect.Type) {
+	l.lintContradiction(nodes)
+	if mayBeNumericString(t) {
+		l.lintLength(nodes)
+	}
+}
+
+// lintContradiction checks whether the operands of AND contradict each other.
+func (l *linter) lintContradiction(nodes []ruleNode) {
+	for i, node := range nodes {
+		for _, prev := range nodes[:i] {
+			if complementary(prev, node) {
+				l.warnf(node, "%s contradicts %s, so the rule never passes", node, prev)
+				return
+			}
+		}
+	}
+
+	var lower, upper float64
+	var lowerNode, upperNode ruleNode
+	for _, node := range nodes {
+		lo, hi, ok := boundsOf(node)
+		if !ok {
+			continue
+		}
+
+		if !math.IsInf(lo, -1) && (lowerNode == nil || lo > lower) {
+			lower, lowerNode = lo, node
+		}
+		if !math.IsInf(hi, 1) && (upperNode == nil || hi < upper) {
+			upper, upperNode = hi, node
+		}
+
+		// The length is compared with the bound truncated to an integer.
+		if lowerNode != nil && upperNode != nil && lower > upper && math.Trunc(lower) > math.Trunc(upper) {
+			if other := lowerNode; other != node {
+				l.warnf(node, "%s contradicts %s, so the rule never passes", node, other)
+			} else if other = upperNode; other != node {
+				l.warnf(node, "%s contradicts %s, so the rule never passes", node, other)
+			} else {
+				l.warnf(node, "%s never passes", node)
+			}
+			return
+		}
+	}
+}
+
+// lintLength checks whether the operands of AND compare the length
+// of the string checked as a number, such as "isinteger && min(1)".
+func (l *linter) lintLength(nodes []ruleNode) {
+	var check, length ruleNode
+	for _, node := range nodes {
+		if name := funcName(node); containsString(numericStringFuncs, name) {
+			check = node
+		} else if n, ok := node.(*callNode); ok && length == nil && containsString(lengthFuncs, n.name) {
+			length = node
+		}
+	}
+
+	if check != nil && length != nil {
+		l.warnf(length, "%s compares the length of the string, not the number checked by %s", length, check)
+	}
+}
+
+// lintOr checks the branches of the chain of OR.
+func (l *linter) lintOr(nodes []ruleNode) {
+	for i, node := range nodes {
+		for _, prev := range nodes[:i] {
+			if implies(node, prev) {
+				l.warnf(node, "the branch %s is unreachable, because the earlier branch %s accepts all the values it accepts", node, prev)
+				break
+			}
+		}
+
+		for _, prev := range nodes[:i] {
+			if complementary(prev, node) && i+1 < len(nodes) {
+				l.warnf(nodes[i+1], "the branch %s is unreachable, because the earlier branches %s and %s accept all the values",
+					nodes[i+1], prev, node)
+				return
+			}
+		}
+	}
+}
+
+// complementary reports whether exactly one of the nodes x and y passes
+// for any value, such as "zero" and "required", "min(1)" and "!min(1)".
+func complementary(x, y ruleNode) bool {
+	if n, ok := x.(*notNode); ok && n.x.String() == y.String() {
+		return true
+	}
+	if n, ok := y.(*notNode); ok && n.x.String() == x.String() {
+		return true
+	}
+
+	xname, yname := funcName(x), funcName(y)
+	return containsString(zeroFuncs, xname) && containsString(notZeroFuncs, yname) ||
+		containsString(notZeroFuncs, xname) && containsString(zeroFuncs, yname)
+}
+
+// implies reports whether the values accepted by the node x
+// are also accepted by the node y.
+func implies(x, y ruleNode) bool {
+	if x.String() == y.String() {
+		return true
+	}
+
+	xlo, xhi, ok := boundsOf(x)
+	if !ok {
+		return false
+	}
+
+	ylo, yhi, ok := boundsOf(y)
+	return ok && ylo <= xlo && xhi <= yhi
+}
+
+// funcName returns the name of the function called without the arguments,
+// such as "zero" or "zero()".
+func funcName(node ruleNode) string {
+	switch n := node.(type) {
+	case *identNode:
+		return n.Name()
+	case *callNode:
+		if len(n.args) == 0 {
+			return n.name
+		}
+	}
+	return ""
+}
+
+// boundsOf returns the bounds of the node of min, max or ranger
+// with the number literals, such as "min(1)" => [1, +Inf].
+func boundsOf(node ruleNode) (lower, upper float64, ok bool) {
+	n, ok := node.(*callNode)
+	if !ok || !containsString(lengthFuncs, n.name) {
+		return 0, 0, false
+	}
+
+	args := make([]float64, len(n.args))
+	for i, arg := range n.args {
+		if args[i], ok = numberOf(arg); !ok {
+			return 0, 0, false
+		}
+	}
+
+	switch {
+	case n.name == "min" && len(args) == 1:
+		return args[0], math.Inf(1), true
+	case n.name == "max" && len(args) == 1:
+		return math.Inf(-1), args[0], true
+	case n.name == "ranger" && len(args) == 2:
+		return args[0], args[1], true
+	default:
+		return 0, 0, false
+	}
+}
+
+func numberOf(node ruleNode) (float64, bool) {
+	if lit, ok := node.(*literalNode); ok {
+		switch v := lit.value.(type) {
+		case int:
+			return float64(v), true
+		case float64:
+			return v, true
+		}
+	}
+	return 0, false
+}
+
+// elemType returns the type of the value validated by the validators
+// as the arguments of the function, such as the element type for array(...).
+func elemType(name string, t reflect.Type) reflect.Type {
+	if t = indirectType(t); t == nil {
+		return nil
+	}
+
+	switch kind := t.Kind(); {
+	case name == "array" && (kind == reflect.Slice || kind == reflect.Array):
+		return t.Elem()
+	case name == "mapk" && kind == reflect.Map:
+		return t.Key()
+	case name == "mapv" && kind == reflect.Map:
+		return t.Elem()
+	case hasEachValidator(name): // Such as mapkv, or the type is unsupported.
+		return nil
+	default: // Such as optional, if, etc.
+		return t
+	}
+}
+
+func hasEachValidator(name string) bool {
+	_, ok := eachValidators[name]
+	return ok
+}
+
+func indirectType(t reflect.Type) reflect.Type {
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t
+}
+
+var (
+	timeType     = reflect.TypeOf(time.Time{})
+	stringerType = reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
+)
+
+// valueTypesOf returns the value types of the type t,
+// and false if the type is unknown, such as an interface.
+func valueTypesOf(t reflect.Type) (types []ValueType, ok bool) {
+	if t = indirectType(t); t == nil || t.Kind() == reflect.Interface {
+		return nil, false
+	}
+
+	// The string validators may validate the result of the method
+	// ValidatedValue or Value. See validator.BoolValidateFunc.
+	for _, name := range []string{"ValidatedValue", "Value"} {
+		if m, ok := reflect.PtrTo(t).MethodByName(name); ok && m.Type.NumIn() == 1 && m.Type.NumOut() == 1 {
+			return nil, false
+		}
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		types = append(types, ValueString)
+	case reflect.Bool:
+		types = append(types, ValueBool)
+	case reflect.Slice, reflect.Array:
+		types = append(types, ValueList)
+	case reflect.Map:
+		types = append(types, ValueMap)
+	case reflect.Struct:
+		if t == timeType {
+			types = append(types, ValueTime)
+		}
+		types = append(types, ValueStruct)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		types = append(types, ValueNumber)
+	}
+
+	if t.Kind() != reflect.String && reflect.PtrTo(t).Implements(stringerType) {
+		types = append(types, ValueString)
+	}
+	return types, true
+}
+
+// mayBeNumericString reports whether the value of the type t may be a string
+// checked as a number, which is true if the type is unknown.
+func mayBeNumericString(t reflect.Type) bool {
+	types, ok := valueTypesOf(t)
+	if !ok {
+		return true
+	}
+
+	var isString, isNumber bool
+	for _, _type := range types {
+		switch _type {
+		case ValueString:
+			isString = true
+		case ValueNumber:
+			isNumber = true
+		}
+	}
+	return isString && !isNumber
+}
@@ -0,0 +1,106 @@
+// Copyright 2025 xgfone
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/xgfone/go-validation/validator"
+)
+
+type lintStringer struct{}
+
+func (lintStringer) String() string { return "" }
+
+func TestBuilderLint(t *testing.T) {
+	b := DefaultBuilder.Extend()
+	b.RegisterSymbol("len", 3)
+	b.RegisterValidator("timelayout", validator.NewValidator("timelayout", func(any) error { return nil }))
+
+	var (
+		stringType = reflect.TypeOf("")
+		intType    = reflect.TypeOf(0)
+	)
+
+	tests := []struct {
+		rule     string
+		typ      reflect.Type
+		warnings []string
+	}{
+		{"min(1) && max(10)", intType, nil},
+		{"isinteger && min(1)", stringType, []string{
+			"1:14: min(1) compares the length of the string, not the number checked by isinteger"}},
+		{"isinteger && min(1)", intType, []string{"1:1: isinteger does not support the type int"}},
+		{"zero && required", nil, []string{"1:9: required contradicts zero, so the rule never passes"}},
+		{"min(1) && !min(1)", nil, []string{"1:11: !min(1) contradicts min(1), so the rule never passes"}},
+		{"min(10) && max(5)", nil, []string{"1:12: max(5) contradicts min(10), so the rule never passes"}},
+		{"ranger(1, 5) && (zero || max(0))", nil, nil},
+		{"ranger(1, 5) && max(0)", nil, []string{"1:17: max(0) contradicts ranger(1, 5), so the rule never passes"}},
+		{"min(1.5) && max(1.2)", nil, nil},
+		{"min(1) || min(5) || zero", nil, []string{
+			"1:11: the branch min(5) is unreachable, because the earlier branch min(1) accepts all the values it accepts"}},
+		{"zero || required || min(1)", nil, []string{
+			"1:21: the branch min(1) is unreachable, because the earlier branches zero and required accept all the values"}},
+		{"within(1h)", stringType, []string{"1:1: within does not support the type string"}},
+		{"within(1h)", reflect.TypeOf(new(time.Time)), nil},
+		{"array(within(1h))", reflect.TypeOf([]string{}), []string{"1:7: within does not support the type string"}},
+		{"mapv(isinteger)", reflect.TypeOf(map[string]int{}), []string{"1:6: isinteger does not support the type int"}},
+		{"optional(isinteger)", reflect.TypeOf(new(int)), []string{"1:10: isinteger does not support the type int"}},
+		{"if(zero, min(1), oneof(\"a\"))", intType, []string{"1:18: oneof does not support the type int"}},
+		{"oneof(\"a\")", reflect.TypeOf(lintStringer{}), nil},
+		{"isinteger", reflect.TypeOf((*any)(nil)).Elem(), nil},
+		{"value > len", nil, []string{"1:9: len refers to the symbol, which shadows the built-in operand"}},
+		{"timelayout", nil, []string{"1:1: timelayout refers to the function, which shadows the symbol"}},
+	}
+
+	for _, test := range tests {
+		warnings, err := b.Lint(test.rule, test.typ)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.rule, err)
+			continue
+		}
+
+		var ss []string
+		for _, w := range warnings {
+			ss = append(ss, w.String())
+		}
+		if !reflect.DeepEqual(ss, test.warnings) {
+			t.Errorf("%s: expect warnings %q, but got %q", test.rule, test.warnings, ss)
+		}
+	}
+
+	if _, err := b.Lint("min(1", nil); err == nil {
+		t.Errorf("expect an error for the invalid rule")
+	}
+}
+
+func ExampleBuilder_Lint() {
+	warnings, err := DefaultBuilder.Lint("isinteger && min(1) && max(0)", reflect.TypeOf(""))
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	for _, w := range warnings {
+		fmt.Println(w)
+	}
+
+	// Output:
+	// 1:24: max(0) contradicts min(1), so the rule never passes
+	// 1:14: min(1) compares the length of the string, not the number checked by isinteger
+}
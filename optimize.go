@@ -0,0 +1,242 @@
+// Copyright 2025 xgfone
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"math"
+	"sort"
+
+	"github.com/xgfone/go-validation/validator"
+	"github.com/xgfone/go-validation/validator/validators"
+)
+
+// DefaultCost is the default cost hint of the validator,
+// which is used by the optimizer if the cost is not given.
+const DefaultCost = 10
+
+// eachCostFactor is the multiple of the cost of the validators
+// applied to each element of the container, such as array(...).
+const eachCostFactor = 10
+
+// SetOptimization enables or disables the optimization of the validators
+// built from the rules by BuildValidator, which is disabled by default.
+//
+// See Optimize.
+func (b *Builder) SetOptimization(enabled bool) {
+	b.lock.Lock()
+	b.optimizing = enabled
+	b.lock.Unlock()
+	b.changed()
+}
+
+// Optimization reports whether the optimization is enabled.
+func (b *Builder) Optimization() bool {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+	return b.optimizing
+}
+
+// Optimize returns a validator optimized from v, which accepts the same set
+// of values as v, but may return a different error for the invalid value.
+//
+// It rewrites the validator tree as follow:
+//
+//   - Flatten the nested And and Or validators.
+//   - Remove the duplicate validators from And and Or.
+//   - Fuse min(a) and max(b) in And into ranger(a, b) if a and b are integers.
+//   - Order the validators of And and Or by the cost ascendingly,
+//     which is registered by WithCost, and is DefaultCost by default.
+//
+// Notice: the order of the validators in And and Or is changed,
+// so they must not depend on each other, for example, a validator
+// panics on the value rejected by the validators before it.
+func (b *Builder) Optimize(v validator.Validator) validator.Validator {
+	n := validator.Inspect(v)
+	children := n.Children()
+	switch n.Kind() {
+	case validator.KindAnd, validator.KindOr:
+		return b.optimizeGroup(n.Kind(), children)
+
+	case validator.KindNot:
+		if child := b.Optimize(children[0]); child.String() != children[0].String() {
+			return validator.Not(child, validator.NotError(n))
+		}
+
+	case validator.KindOptional:
+		return validator.Optional(b.Optimize(children[0]))
+
+	case validator.KindIf:
+		var otherwise validator.Validator
+		if len(children) > 2 {
+			otherwise = b.Optimize(children[2])
+		}
+		return validator.If(b.Optimize(children[0]), b.Optimize(children[1]), otherwise)
+
+	case validator.KindEach:
+		if newf, ok := eachValidators[n.Name()]; ok {
+			return newf(b.optimizeGroup(validator.KindAnd, children))
+		}
+	}
+
+	return v
+}
+
+var eachValidators = map[string]func(...validator.Validator) validator.Validator{
+	"array": validators.Array,
+	"mapk":  validators.MapK,
+	"mapv":  validators.MapV,
+	"mapkv": validators.MapKV,
+}
+
+func (b *Builder) optimizeGroup(kind validator.Kind, children []validator.Validator) validator.Validator {
+	vs := make([]validator.Validator, 0, len(children))
+	seen := make(map[string]struct{}, len(children))
+	for _, child := range children {
+		child = b.Optimize(child)
+
+		// The optimized sub-group has been flattened, deduplicated and ordered.
+		grandchildren := []validator.Validator{child}
+		if n := validator.Inspect(child); n.Kind() == kind {
+			grandchildren = n.Children()
+		}
+
+		for _, v := range grandchildren {
+			if s := v.String(); !hasKey(seen, s) {
+				seen[s] = struct{}{}
+				vs = append(vs, v)
+			}
+		}
+	}
+
+	if kind == validator.KindAnd {
+		vs = fuseRange(vs)
+	}
+
+	costs := make(map[string]int, len(vs))
+	for _, v := range vs {
+		costs[v.String()] = b.cost(v)
+	}
+	sort.SliceStable(vs, func(i, j int) bool {
+		return costs[vs[i].String()] < costs[vs[j].String()]
+	})
+
+	if kind == validator.KindAnd {
+		return validator.And(vs...)
+	}
+	return validator.Or(vs...)
+}
+
+func hasKey(m map[string]struct{}, key string) bool {
+	_, ok := m[key]
+	return ok
+}
+
+// cost returns the cost hint of the validator.
+func (b *Builder) cost(v validator.Validator) (cost int) {
+	n := validator.Inspect(v)
+	if n.Kind() == validator.KindLeaf {
+		return b.funcCost(n.Name())
+	}
+
+	for _, child := range n.Children() {
+		cost += b.cost(child)
+	}
+	if n.Kind() == validator.KindEach {
+		cost *= eachCostFactor
+	}
+	return
+}
+
+// funcCost returns the cost hint of the function by the name,
+// which falls back to the parents if not found.
+func (b *Builder) funcCost(name string) int {
+	if info, ok := b.lookupFuncInfo(name); ok && info.Cost > 0 {
+		return info.Cost
+	}
+	return DefaultCost
+}
+
+// fuseRange fuses all the integer min and max validators into one,
+// such as "min(1) && max(10) && min(2)" => "ranger(2, 10)",
+// which is placed at the position of the first one.
+//
+// The bounds must be integers, because the length of the string
+// or container is compared with the bound truncated to an integer
+// by min and max, but with the original bound by ranger.
+func fuseRange(vs []validator.Validator) []validator.Validator {
+	var mins, maxs int
+	var lower, upper float64
+	for _, v := range vs {
+		switch name, bound, ok := rangeBound(v); {
+		case !ok:
+		case name == "min":
+			if mins == 0 || bound > lower {
+				lower = bound
+			}
+			mins++
+		default:
+			if maxs == 0 || bound < upper {
+				upper = bound
+			}
+			maxs++
+		}
+	}
+
+	var fused validator.Validator
+	switch {
+	case mins+maxs < 2:
+		return vs
+	case maxs == 0:
+		fused = validators.Min(lower)
+	case mins == 0:
+		fused = validators.Max(upper)
+	default:
+		fused = validators.Ranger(lower, upper)
+	}
+
+	results := make([]validator.Validator, 0, len(vs)-mins-maxs+1)
+	for _, v := range vs {
+		if _, _, ok := rangeBound(v); !ok {
+			results = append(results, v)
+		} else if fused != nil {
+			results = append(results, fused)
+			fused = nil
+		}
+	}
+	return results
+}
+
+// rangeBound returns the integer bound of the min or max validator
+// built by validators.Min or validators.Max.
+func rangeBound(v validator.Validator) (name string, bound float64, ok bool) {
+	n := validator.Inspect(v)
+	if n.Kind() != validator.KindLeaf || len(n.Args()) != 1 {
+		return
+	}
+
+	if bound, ok = n.Args()[0].(float64); !ok || bound != math.Trunc(bound) || math.Abs(bound) > 1<<53 {
+		return "", 0, false
+	}
+
+	switch name = n.Name(); name {
+	case "min":
+		ok = v.String() == validators.Min(bound).String()
+	case "max":
+		ok = v.String() == validators.Max(bound).String()
+	default:
+		ok = false
+	}
+	return
+}
@@ -0,0 +1,92 @@
+// Copyright 2025 xgfone
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/xgfone/go-validation/validator"
+	"github.com/xgfone/go-validation/validator/validators"
+)
+
+func TestBuilderOptimize(t *testing.T) {
+	base := DefaultBuilder.Extend()
+	base.RegisterFunction(WithCost(ValidatorFunction("slow", validator.NewValidator("slow", func(v any) error {
+		if v == 2 {
+			return errors.New("slow")
+		}
+		return nil
+	})), 1000))
+
+	b := base.Extend()
+	b.SetOptimization(true)
+	if !b.Extend().Optimization() {
+		t.Errorf("expect the child builder to inherit the optimization")
+	}
+
+	tests := []struct {
+		rule   string
+		expect string
+	}{
+		{"min(1) && max(10) && min(1)", "ranger(1, 10)"},
+		{"min(1) && (zero || min(1)) && max(10) && min(3)", "ranger(3, 10) && (zero || min(1))"},
+		{"min(1.5) && max(10)", "min(1.5) && max(10)"},
+		{"max(10) && max(5)", "max(5)"},
+		{`slow && regexp("^a+$") && max(254)`, `max(254) && regexp("^a+$") && slow`},
+		{`slow || (zero || slow) || min(1)`, `(zero || min(1) || slow)`},
+		{"!(slow && min(1) && min(1))", "!(min(1) && slow)"},
+		{"array(slow, min(1), max(3))", "array(ranger(1, 3) && slow)"},
+		{"optional(min(1) && max(3))", "optional(ranger(1, 3))"},
+		{"if(zero, slow && min(1), max(3) && max(1))", "if(zero, (min(1) && slow), max(1))"},
+		{"min(1) && value != 5 && max($max)", "min(1) && value != 5 && max($max)"},
+	}
+
+	for _, test := range tests {
+		v, err := b.BuildValidator(test.rule)
+		if err != nil {
+			t.Errorf("%s: %v", test.rule, err)
+		} else if s := v.String(); s != test.expect && s != "("+test.expect+")" {
+			t.Errorf("%s: expect '%s', but got '%s'", test.rule, test.expect, s)
+		}
+	}
+
+	values := []any{nil, 0, 1, 2, 5, 11, "", "a", "aaaa", "aaaaaaaaaaaa", []int{1}, []int{0, 2, 4}}
+	for _, test := range tests[:len(tests)-1] {
+		for _, value := range values {
+			err1 := base.TryValidate(value, test.rule)
+			err2 := b.TryValidate(value, test.rule)
+			if (err1 == nil) != (err2 == nil) {
+				t.Errorf("%s: %v: expect '%v', but got '%v'", test.rule, value, err1, err2)
+			}
+		}
+	}
+}
+
+func TestBuilderOptimizeNot(t *testing.T) {
+	errNot := errors.New("must not be in [1, 3]")
+	child := validator.And(validators.Min(1), validators.Max(3), validators.Min(1))
+	v := DefaultBuilder.Optimize(validator.Not(child, errNot))
+	if s := v.String(); s != "!ranger(1, 3)" {
+		t.Errorf("expect '%s', but got '%s'", "!ranger(1, 3)", s)
+	} else if err := v.Validate(2); err != errNot {
+		t.Errorf("expect the error '%v', but got '%v'", errNot, err)
+	}
+
+	unchanged := validator.Not(validators.Min(1), errNot)
+	if v := DefaultBuilder.Optimize(unchanged); validator.NotError(v) != errNot {
+		t.Errorf("expect the original NOT validator, but got '%v'", v)
+	}
+}
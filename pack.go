@@ -0,0 +1,161 @@
+// Copyright 2025 xgfone
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"fmt"
+
+	"github.com/xgfone/go-validation/validator"
+	"github.com/xgfone/go-validation/validator/str"
+	"github.com/xgfone/go-validation/validator/validators"
+)
+
+// Pack is a group of the validators installed into the builder
+// by the namespace, which is referred to by the dotted selector in the rule,
+// such as "str.email", "net.ipv4", etc.
+type Pack interface {
+	// Name returns the namespace of the pack, such as "str".
+	Name() string
+
+	// Register registers the validators into the namespace.
+	Register(ns Namespace)
+}
+
+// NewPack returns a new Pack with the namespace and the register function.
+func NewPack(name string, register func(ns Namespace)) Pack {
+	if !isIdentifier(name) {
+		panic(fmt.Errorf("invalid pack name '%s'", name))
+	}
+	if register == nil {
+		panic("the pack register function must not be nil")
+	}
+	return packImpl{name: name, register: register}
+}
+
+type packImpl struct {
+	register func(Namespace)
+	name     string
+}
+
+func (p packImpl) Name() string          { return p.name }
+func (p packImpl) Register(ns Namespace) { p.register(ns) }
+
+// Install is equal to DefaultBuilder.Install(packs...).
+func Install(packs ...Pack) {
+	DefaultBuilder.Install(packs...)
+}
+
+// Install installs the packs into the builder by their namespaces.
+func (b *Builder) Install(packs ...Pack) {
+	for _, pack := range packs {
+		pack.Register(b.Namespace(pack.Name()))
+	}
+}
+
+// Namespace returns the namespace of the builder with the name,
+// which may be a dotted name, such as "str", "fin.bank", etc.
+func (b *Builder) Namespace(name string) Namespace {
+	if !isIdentifier(name) {
+		panic(fmt.Errorf("invalid namespace '%s'", name))
+	}
+	return Namespace{builder: b, name: name}
+}
+
+// Namespace is used to register the validators and symbols into the builder
+// with the name prefix of the namespace, such as "str.email".
+type Namespace struct {
+	builder *Builder
+	name    string
+}
+
+// Name returns the name of the namespace.
+func (ns Namespace) Name() string { return ns.name }
+
+// Builder returns the builder that the namespace belongs to.
+func (ns Namespace) Builder() *Builder { return ns.builder }
+
+// FullName returns the full name in the namespace, such as "str.email".
+func (ns Namespace) FullName(name string) string { return ns.name + "." + name }
+
+// RegisterSymbol registers the symbol with the name in the namespace.
+func (ns Namespace) RegisterSymbol(name string, value any) {
+	ns.builder.RegisterSymbol(ns.FullName(name), value)
+}
+
+// RegisterFunction registers the builder function in the namespace,
+// the name of which is prefixed by the namespace.
+func (ns Namespace) RegisterFunction(function Function) {
+	info := GetFuncInfo(function)
+	info.Name = ns.FullName(info.Name)
+	ns.builder.RegisterFunction(NewFunctionWithInfo(info, function.Call))
+}
+
+// RegisterValidator registers the validator with the name in the namespace.
+func (ns Namespace) RegisterValidator(name string, v validator.Validator) {
+	ns.builder.RegisterValidator(ns.FullName(name), v)
+}
+
+// RegisterValidatorFunc registers the validator function with the name
+// in the namespace, the validator rule of which is the full name.
+func (ns Namespace) RegisterValidatorFunc(name string, f validator.ValidateFunc) {
+	ns.builder.RegisterValidatorFunc(ns.FullName(name), f)
+}
+
+// RegisterValidatorOneof registers a oneof validator with the name
+// in the namespace, the validator rule of which is the full name.
+func (ns Namespace) RegisterValidatorOneof(name string, values ...string) {
+	ns.builder.RegisterValidatorOneof(ns.FullName(name), values...)
+}
+
+// RegisterRule registers the named rule with the name in the namespace.
+func (ns Namespace) RegisterRule(name, rule string) error {
+	return ns.builder.RegisterRule(ns.FullName(name), rule)
+}
+
+// ************************************************************************* //
+
+// StrPack is the pack of the string validators with the namespace "str",
+// which is the same as RegisterStringValidatorsForBuilder but without
+// the prefix "is", such as "str.email", "str.uuid", "str.ascii", etc.
+var StrPack = NewPack("str", func(ns Namespace) {
+	for _, v := range strValidators {
+		ns.RegisterFunction(newStrFunction(v.name, ns.FullName(v.name), v.name, v.f))
+	}
+})
+
+// NetPack is the pack of the network validators with the namespace "net":
+//
+//	net.ip
+//	net.ipv4
+//	net.ipv6
+//	net.cidr
+//	net.mac
+//	net.host
+//	net.port
+//	net.dnsname
+//	net.addr: HOST:PORT
+//	net.url
+var NetPack = NewPack("net", func(ns Namespace) {
+	registerStrValidatorFunc(ns.RegisterValidatorFunc, str.IsIP, "ip")
+	registerStrValidatorFunc(ns.RegisterValidatorFunc, str.IsIPv4, "ipv4")
+	registerStrValidatorFunc(ns.RegisterValidatorFunc, str.IsIPv6, "ipv6")
+	registerStrValidatorFunc(ns.RegisterValidatorFunc, str.IsCIDR, "cidr")
+	registerStrValidatorFunc(ns.RegisterValidatorFunc, str.IsMAC, "mac")
+	registerStrValidatorFunc(ns.RegisterValidatorFunc, str.IsHost, "host")
+	registerStrValidatorFunc(ns.RegisterValidatorFunc, str.IsPort, "port")
+	registerStrValidatorFunc(ns.RegisterValidatorFunc, str.IsDNSName, "dnsname")
+	ns.RegisterValidatorFunc("addr", validators.Addr().Validate)
+	ns.RegisterValidatorFunc("url", validators.Url().Validate)
+})
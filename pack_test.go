@@ -0,0 +1,96 @@
+// Copyright 2025 xgfone
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/xgfone/go-validation/validator/validators"
+)
+
+func ExampleBuilder_Install() {
+	builder := NewBuilder()
+	RegisterDefaultsForBuilder(builder)
+	builder.Install(StrPack, NetPack)
+
+	fmt.Println(builder.Validate("abc@example.com", "str.email"))
+	fmt.Println(builder.Validate("abc", "str.email"))
+	fmt.Println(builder.Validate("1.2.3.4", "net.ipv4"))
+	fmt.Println(builder.Validate("::1", "net.ipv4 || net.ipv6"))
+	fmt.Println(builder.Validate("1.2.3.4", "net.ipv6"))
+
+	// Output:
+	// <nil>
+	// the string is not email
+	// <nil>
+	// <nil>
+	// the string is not ipv6
+}
+
+func TestPack(t *testing.T) {
+	fin := NewPack("fin", func(ns Namespace) {
+		ns.RegisterSymbol("maxlen", 34)
+		ns.RegisterValidatorOneof("currency", "CNY", "USD")
+		ns.RegisterFunction(NewFunctionWithOneFloat("amount", validators.Min))
+		if err := ns.RegisterRule("iban", "min(15) && max(fin.maxlen)"); err != nil {
+			panic(err)
+		}
+	})
+
+	builder := NewBuilder()
+	RegisterDefaultsForBuilder(builder)
+	builder.Install(fin)
+
+	tests := []struct {
+		value any
+		rule  string
+		valid bool
+	}{
+		{"USD", "fin.currency", true},
+		{"EUR", "fin.currency", false},
+		{10, "fin.amount(1)", true},
+		{0, "fin.amount(1)", false},
+		{"GB82WEST12345698765432", "fin.iban", true},
+		{"GB82WEST", "fin.iban", false},
+		{"GB82WEST", "max(fin.maxlen)", true},
+	}
+	for _, test := range tests {
+		if err := builder.TryValidate(test.value, test.rule); test.valid && err != nil {
+			t.Errorf("%s: unexpected error: %v", test.rule, err)
+		} else if !test.valid && err == nil {
+			t.Errorf("%s: expect an error, but got nil", test.rule)
+		}
+	}
+
+	if v := builder.Rules()["fin.iban"]; v != "min(15) && max(fin.maxlen)" {
+		t.Errorf("unexpected rule '%s'", v)
+	}
+
+	if err := builder.TryValidate("USD", "fin.unknown"); err == nil {
+		t.Errorf("expect an error, but got nil")
+	} else if s := err.Error(); s != "invalid rule 'fin.unknown': 1:1: fin.unknown is not defined" {
+		t.Errorf("unexpected error '%s'", s)
+	}
+	builder.Install(StrPack)
+	if v, err := builder.BuildValidator("str.existingemail"); err != nil {
+		t.Error(err)
+	} else if s := v.String(); s != "str.existingemail" {
+		t.Errorf("expect the rule 'str.existingemail', but got '%s'", s)
+	}
+	if info, _ := builder.Catalog().Lookup("str.existingemail"); info.Cost != 1000 {
+		t.Errorf("expect the cost 1000, but got %d", info.Cost)
+	}
+}
@@ -0,0 +1,530 @@
+// Copyright 2025 xgfone
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"errors"
+	"fmt"
+	"go/scanner"
+	"go/token"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The syntax of the validation rule is a subset of the Go expression:
+//
+//	expr    = or
+//	or      = and { "||" and }
+//	and     = compare { "&&" compare }
+//	compare = unary { ( "==" | "!=" | "<" | "<=" | ">" | ">=" ) unary }
+//	unary   = ( "!" | "-" ) unary | primary
+//	primary = literal | list | var | ident [ "(" [ arg { "," arg } [ "," ] ] ")" ] | "(" expr ")"
+//	var     = "$" ident
+//	arg     = [ name "=" ] expr
+//	list    = "[" [ expr { "," expr } [ "," ] ] "]"
+//	ident   = name { "." name }
+//	literal = int | float | string | bool | duration
+//	bool    = "true" | "false"
+//
+// The duration is an int or float immediately followed by the unit,
+// such as "500ms", "24h", "1h30m", which is parsed by time.ParseDuration.
+
+type ruleNode interface {
+	Pos() int       // The byte offset of the node in the rule.
+	End() int       // The byte offset immediately after the node.
+	String() string // The rule of the node.
+}
+
+type (
+	// binaryNode is the node of "x op y", such as "x && y", "x == y", etc.
+	binaryNode struct {
+		pos int // The byte offset of the operator.
+		op  token.Token
+		x   ruleNode
+		y   ruleNode
+	}
+
+	// notNode is the node of "!x".
+	notNode struct {
+		pos int
+		x   ruleNode
+	}
+
+	// callNode is the node of the function call, such as "min(1)".
+	callNode struct {
+		pos  int
+		end  int
+		name string
+		args []ruleNode
+	}
+
+	// identNode is the node of the identifier, such as "zero", "a.b".
+	identNode struct {
+		pos      int
+		end      int
+		selector []string
+	}
+
+	// literalNode is the node of the int, float64, string, bool
+	// or time.Duration literal.
+	literalNode struct {
+		pos   int
+		end   int
+		value any
+	}
+
+	// listNode is the node of the list literal, such as `["a", "b"]`.
+	listNode struct {
+		pos   int
+		end   int
+		elems []ruleNode
+	}
+
+	// kwargNode is the node of the keyword argument, such as `loc="UTC"`.
+	kwargNode struct {
+		pos   int
+		name  string
+		value ruleNode
+	}
+
+	// varNode is the node of the runtime variable, such as "$quota",
+	// which is resolved when validating the value.
+	varNode struct {
+		pos  int
+		end  int
+		name string
+	}
+)
+
+func (n *binaryNode) Pos() int  { return n.x.Pos() }
+func (n *notNode) Pos() int     { return n.pos }
+func (n *callNode) Pos() int    { return n.pos }
+func (n *identNode) Pos() int   { return n.pos }
+func (n *literalNode) Pos() int { return n.pos }
+func (n *listNode) Pos() int    { return n.pos }
+func (n *kwargNode) Pos() int   { return n.pos }
+func (n *varNode) Pos() int     { return n.pos }
+
+func (n *binaryNode) End() int  { return n.y.End() }
+func (n *notNode) End() int     { return n.x.End() }
+func (n *callNode) End() int    { return n.end }
+func (n *identNode) End() int   { return n.end }
+func (n *literalNode) End() int { return n.end }
+func (n *listNode) End() int    { return n.end }
+func (n *kwargNode) End() int   { return n.value.End() }
+func (n *varNode) End() int     { return n.end }
+
+func (n *identNode) Name() string { return strings.Join(n.selector, ".") }
+
+func (n *identNode) String() string   { return n.Name() }
+func (n *varNode) String() string     { return "$" + n.name }
+func (n *literalNode) String() string { return formatLiteral(n.value) }
+func (n *kwargNode) String() string   { return n.name + "=" + n.value.String() }
+func (n *listNode) String() string    { return "[" + joinNodes(n.elems) + "]" }
+func (n *callNode) String() string    { return n.name + "(" + joinNodes(n.args) + ")" }
+
+func (n *notNode) String() string {
+	if _, ok := n.x.(*binaryNode); ok {
+		return "!(" + n.x.String() + ")"
+	}
+	return "!" + n.x.String()
+}
+
+func (n *binaryNode) String() string {
+	prec := n.op.Precedence()
+	x, y := n.x.String(), n.y.String()
+	if b, ok := n.x.(*binaryNode); ok && b.op.Precedence() < prec {
+		x = "(" + x + ")"
+	}
+	if b, ok := n.y.(*binaryNode); ok && b.op.Precedence() <= prec {
+		y = "(" + y + ")"
+	}
+	return x + " " + n.op.String() + " " + y
+}
+
+func joinNodes(nodes []ruleNode) string {
+	ss := make([]string, len(nodes))
+	for i, node := range nodes {
+		ss[i] = node.String()
+	}
+	return strings.Join(ss, ", ")
+}
+
+func formatLiteral(value any) string {
+	switch v := value.(type) {
+	case string:
+		return strconv.Quote(v)
+	case float64:
+		s := strconv.FormatFloat(v, 'g', -1, 64)
+		if !strings.ContainsAny(s, ".eIN") {
+			s += ".0"
+		}
+		return s
+	case []any:
+		ss := make([]string, len(v))
+		for i, e := range v {
+			ss[i] = formatLiteral(e)
+		}
+		return "[" + strings.Join(ss, ", ") + "]"
+	default:
+		return fmt.Sprint(value)
+	}
+}
+
+// ************************************************************************* //
+
+type ruleParser struct {
+	file    *token.File
+	scanner scanner.Scanner
+	errs    scanner.ErrorList
+
+	pos int // The byte offset of the current token.
+	end int // The byte offset immediately after the previous token.
+	tok token.Token
+	lit string
+}
+
+func parseRule(rule string) (node ruleNode, err error) {
+	var p ruleParser
+	p.file = token.NewFileSet().AddFile("", -1, len(rule))
+	p.scanner.Init(p.file, []byte(rule), p.addError, 0)
+
+	p.next()
+	if node, err = p.parseExpr(); err == nil && p.tok != token.EOF {
+		err = p.unexpected()
+	}
+
+	if len(p.errs) > 0 { // Prefer the scanning error.
+		err = p.scanError()
+	}
+	return
+}
+
+// scanError converts the first scanning error to PosError.
+func (p *ruleParser) scanError() error {
+	e := p.errs[0]
+	return &PosError{Offset: e.Pos.Offset, Line: e.Pos.Line, Column: e.Pos.Column, Err: errors.New(e.Msg)}
+}
+
+func (p *ruleParser) addError(pos token.Position, msg string) {
+	if msg != "illegal character U+0024 '$'" { // "$" is used by the variable.
+		p.errs.Add(pos, msg)
+	}
+}
+
+func (p *ruleParser) next() {
+	p.end = p.pos + len(p.tokString())
+	for {
+		var pos token.Pos
+		pos, p.tok, p.lit = p.scanner.Scan()
+		p.pos = p.file.Offset(pos)
+
+		// Ignore the semicolon inserted automatically at the newline or EOF.
+		if p.tok != token.SEMICOLON || p.lit != "\n" {
+			break
+		}
+	}
+
+	// Allow to use the keyword as the name, such as "if".
+	if p.tok.IsKeyword() {
+		p.tok = token.IDENT
+	}
+}
+
+func (p *ruleParser) errorf(pos int, format string, args ...any) error {
+	position := p.file.Position(p.file.Pos(pos))
+	err := &PosError{Offset: pos, Line: position.Line, Column: position.Column, Err: fmt.Errorf(format, args...)}
+	if pos == p.pos && p.tok != token.EOF {
+		err.Token = p.tokString()
+	}
+	return err
+}
+
+func (p *ruleParser) unexpected() error {
+	switch {
+	case p.tok == token.EOF:
+		return p.errorf(p.pos, "unexpected EOF")
+	case p.lit != "":
+		return p.errorf(p.pos, "unexpected %s", p.lit)
+	default:
+		return p.errorf(p.pos, "unexpected %s", p.tok)
+	}
+}
+
+func (p *ruleParser) expect(tok token.Token) error {
+	if p.tok != tok {
+		return p.errorf(p.pos, "expected '%s', but got '%s'", tok, p.tokString())
+	}
+	p.next()
+	return nil
+}
+
+func (p *ruleParser) tokString() string {
+	if p.lit != "" && p.tok != token.SEMICOLON {
+		return p.lit
+	}
+	return p.tok.String()
+}
+
+func (p *ruleParser) parseExpr() (ruleNode, error) {
+	return p.parseBinary(token.LowestPrec + 1)
+}
+
+func (p *ruleParser) parseBinary(prec int) (x ruleNode, err error) {
+	if x, err = p.parseUnary(); err != nil {
+		return
+	}
+
+	for {
+		op, pos := p.tok, p.pos
+		oprec := op.Precedence()
+		if oprec < prec {
+			return x, nil
+		}
+
+		switch op {
+		case token.LOR, token.LAND, token.EQL, token.NEQ,
+			token.LSS, token.LEQ, token.GTR, token.GEQ:
+		default:
+			return nil, p.errorf(pos, "unsupported operator '%s'", op)
+		}
+
+		p.next()
+		y, err := p.parseBinary(oprec + 1)
+		if err != nil {
+			return nil, err
+		}
+
+		x = &binaryNode{pos: pos, op: op, x: x, y: y}
+	}
+}
+
+func (p *ruleParser) parseUnary() (ruleNode, error) {
+	switch pos := p.pos; p.tok {
+	case token.NOT:
+		p.next()
+		x, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &notNode{pos: pos, x: x}, nil
+
+	case token.SUB:
+		p.next()
+		x, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+
+		if lit, ok := x.(*literalNode); ok {
+			switch v := lit.value.(type) {
+			case int:
+				return &literalNode{pos: pos, end: lit.end, value: -v}, nil
+			case float64:
+				return &literalNode{pos: pos, end: lit.end, value: -v}, nil
+			case time.Duration:
+				return &literalNode{pos: pos, end: lit.end, value: -v}, nil
+			}
+		}
+		return nil, p.errorf(pos, "- expects an integer, float or duration")
+
+	default:
+		return p.parsePrimary()
+	}
+}
+
+func (p *ruleParser) parsePrimary() (ruleNode, error) {
+	switch p.tok {
+	case token.INT, token.FLOAT, token.STRING:
+		return p.parseLiteral()
+
+	case token.IDENT:
+		return p.parseIdent()
+
+	case token.LBRACK:
+		return p.parseList()
+
+	case token.ILLEGAL:
+		if p.lit != "$" {
+			break
+		}
+
+		return p.parseVar()
+
+	case token.LPAREN:
+		p.next()
+		x, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		if err = p.expect(token.RPAREN); err != nil {
+			return nil, err
+		}
+		return x, nil
+
+	}
+
+	if p.tok == token.ILLEGAL && len(p.errs) > 0 {
+		return nil, p.scanError()
+	}
+	return nil, p.errorf(p.pos, "unexpected '%s'", p.tokString())
+}
+
+func (p *ruleParser) parseLiteral() (ruleNode, error) {
+	var err error
+	var value any
+	switch p.tok {
+	case token.INT:
+		value, err = strconv.Atoi(p.lit)
+	case token.FLOAT:
+		value, err = strconv.ParseFloat(p.lit, 64)
+	case token.STRING:
+		value, err = strconv.Unquote(p.lit)
+	}
+
+	if err != nil {
+		return nil, p.errorf(p.pos, "invalid literal %s: %v", p.lit, err)
+	}
+
+	node := &literalNode{pos: p.pos, value: value}
+	tok, lit := p.tok, p.lit
+	p.next()
+	node.end = p.end
+
+	// The number is immediately followed by the unit, such as "24h".
+	if (tok == token.INT || tok == token.FLOAT) && p.tok == token.IDENT && p.pos == node.pos+len(lit) {
+		lit += p.lit
+		if node.value, err = time.ParseDuration(lit); err != nil {
+			return nil, p.errorf(node.pos, "invalid duration %s", lit)
+		}
+		p.next()
+		node.end = p.end
+	}
+
+	return node, nil
+}
+
+func (p *ruleParser) parseList() (ruleNode, error) {
+	node := &listNode{pos: p.pos}
+	for p.next(); p.tok != token.RBRACK; {
+		elem, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		node.elems = append(node.elems, elem)
+
+		if p.tok != token.COMMA {
+			break
+		}
+		p.next()
+	}
+
+	if err := p.expect(token.RBRACK); err != nil {
+		return nil, err
+	}
+	node.end = p.end
+	return node, nil
+}
+
+func (p *ruleParser) parseVar() (ruleNode, error) {
+	pos := p.pos
+	if p.next(); p.tok != token.IDENT || p.pos != pos+1 {
+		return nil, p.errorf(pos, "expected variable name after '$'")
+	}
+
+	selector, err := p.parseSelector()
+	if err != nil {
+		return nil, err
+	}
+	return &varNode{pos: pos, end: p.end, name: strings.Join(selector, ".")}, nil
+}
+
+func (p *ruleParser) parseSelector() ([]string, error) {
+	selector := []string{p.lit}
+	for p.next(); p.tok == token.PERIOD; {
+		p.next()
+		if p.tok != token.IDENT {
+			return nil, p.errorf(p.pos, "expected name, but got '%s'", p.tokString())
+		}
+		selector = append(selector, p.lit)
+		p.next()
+	}
+	return selector, nil
+}
+
+func (p *ruleParser) parseIdent() (ruleNode, error) {
+	pos := p.pos
+	selector, err := p.parseSelector()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.tok != token.LPAREN {
+		if len(selector) == 1 && (selector[0] == "true" || selector[0] == "false") {
+			return &literalNode{pos: pos, end: p.end, value: selector[0] == "true"}, nil
+		}
+		return &identNode{pos: pos, end: p.end, selector: selector}, nil
+	}
+
+	var args []ruleNode
+	kwargs := make(map[string]bool)
+	for p.next(); p.tok != token.RPAREN; {
+		arg, err := p.parseArg()
+		if err != nil {
+			return nil, err
+		}
+
+		if kw, ok := arg.(*kwargNode); ok {
+			if kwargs[kw.name] {
+				return nil, p.errorf(kw.pos, "duplicate keyword argument '%s'", kw.name)
+			}
+			kwargs[kw.name] = true
+		} else if len(kwargs) > 0 {
+			return nil, p.errorf(arg.Pos(), "positional argument follows keyword argument")
+		}
+		args = append(args, arg)
+
+		if p.tok != token.COMMA {
+			break
+		}
+		p.next()
+	}
+
+	if err := p.expect(token.RPAREN); err != nil {
+		return nil, err
+	}
+
+	return &callNode{pos: pos, end: p.end, name: strings.Join(selector, "."), args: args}, nil
+}
+
+func (p *ruleParser) parseArg() (ruleNode, error) {
+	arg, err := p.parseExpr()
+	if err != nil || p.tok != token.ASSIGN {
+		return arg, err
+	}
+
+	ident, ok := arg.(*identNode)
+	if !ok || len(ident.selector) != 1 {
+		return nil, p.errorf(arg.Pos(), "the keyword argument name must be an identifier")
+	}
+
+	p.next()
+	value, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+	return &kwargNode{pos: ident.pos, name: ident.selector[0], value: value}, nil
+}
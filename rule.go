@@ -0,0 +1,324 @@
+// Copyright 2025 xgfone
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"fmt"
+	"go/token"
+	"strings"
+
+	"github.com/xgfone/go-validation/validator"
+)
+
+// namedRule is the validation rule registered with a name as a macro.
+type namedRule struct {
+	rule      string
+	deps      []string // The names of the other named rules referred to.
+	validator validator.Validator
+}
+
+// RegisterRule is equal to DefaultBuilder.RegisterRule(name, rule).
+func RegisterRule(name, rule string) error {
+	return DefaultBuilder.RegisterRule(name, rule)
+}
+
+// RegisterRule registers the validation rule with the name as a macro,
+// which is compiled once and can be used as an identifier in other rules.
+// For example,
+//
+//	b.RegisterRule("username", `min(3) && max(32) && regexp("^[a-z0-9_]+$")`)
+//	b.Validate("root", `username && !oneof("admin", "root")`)
+//
+// If the rule has been registered, reset it to the new rule and rebuild
+// all the rules depending on it, which is atomic, that's, nothing is changed
+// if failing to rebuild any of them. But it returns an error if the name has
+// been registered as a builder function, the rule is invalid, or the rule
+// refers to itself directly or indirectly. For the invalid rule,
+// the returned error is *RuleError.
+//
+// Notice: it will clear the cache of the built validators.
+func (b *Builder) RegisterRule(name, rule string) (err error) {
+	if !isIdentifier(name) {
+		return fmt.Errorf("invalid rule name '%s'", name)
+	}
+
+	b.ruleLock.Lock()
+	defer b.ruleLock.Unlock()
+
+	if b.Frozen() {
+		return ErrFrozen
+	}
+
+	_, exist := b.rules[name]
+	if !exist && b.hasOwnFunc(name) {
+		return fmt.Errorf("%s has been registered as a function", name)
+	}
+
+	node, err := parseRule(rule)
+	if err != nil {
+		return NewRuleError(name, rule, err)
+	}
+
+	deps := b.ruleDeps(name, node)
+	if path := b.findRuleCycle(name, deps, nil); len(path) > 0 {
+		return fmt.Errorf("rule cycle is detected: %s", strings.Join(path, " -> "))
+	}
+
+	r, err := b.compileRule(rule, node)
+	if err != nil {
+		return NewRuleError(name, rule, err)
+	}
+	r.rule = rule
+	r.deps = deps
+
+	// Compile all the dependent rules against the new rule at first,
+	// and commit them only if all of them succeed.
+	var dependents map[string]*namedRule
+	if exist {
+		if dependents, err = b.compileRuleDependents(name, r); err != nil {
+			return
+		}
+	}
+
+	if err = b.setRules(name, r, !exist, dependents); err != nil {
+		return
+	}
+
+	b.changed()
+	return
+}
+
+// Rules returns all the registered named rules, including those of the parents,
+// the key of which is the name and the value of which is the rule.
+func (b *Builder) Rules() map[string]string {
+	rules := make(map[string]string)
+	b.walkFuncs(func(name string, r *namedRule, _ FuncInfo) {
+		if r != nil {
+			rules[name] = r.rule
+		}
+	})
+	return rules
+}
+
+// lookupRule returns the named rule by the name, which falls back to
+// the parents. Return nil if the function of the name is not a named rule.
+func (b *Builder) lookupRule(name string) *namedRule {
+	for ; b != nil; b = b.parent {
+		b.lock.RLock()
+		_, ok := b.funcs[name]
+		r := b.rules[name]
+		b.lock.RUnlock()
+
+		if ok {
+			return r
+		}
+	}
+	return nil
+}
+
+func (b *Builder) hasOwnFunc(name string) bool {
+	b.lock.RLock()
+	_, ok := b.funcs[name]
+	b.lock.RUnlock()
+	return ok
+}
+
+// setRules sets the named rule and its dependent rules atomically.
+func (b *Builder) setRules(name string, r *namedRule, register bool, dependents map[string]*namedRule) error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if b.frozen {
+		return ErrFrozen
+	}
+
+	for dep, dr := range dependents {
+		b.rules[dep] = dr
+	}
+
+	b.rules[name] = r
+	if register {
+		b.funcs[name] = toBuilderFunction(NewFunction(name, b.callRule(name)))
+		b.infos[name] = FuncInfo{Name: name, Params: []Param{}}
+	}
+	return nil
+}
+
+func (b *Builder) callRule(name string) func(*Context, ...any) error {
+	return func(c *Context, args ...any) error {
+		if len(args) > 0 {
+			return fmt.Errorf("%s must not have any arguments", name)
+		}
+
+		b.lock.RLock()
+		r, ok := b.rules[name]
+		b.lock.RUnlock()
+
+		if !ok {
+			return fmt.Errorf("%s is not defined", name)
+		}
+		c.AppendValidators(r.validator)
+		return nil
+	}
+}
+
+func (b *Builder) compileRule(rule string, node ruleNode) (*namedRule, error) {
+	c := NewContext()
+	if err := b.buildRoot(c, rule, node); err != nil {
+		return nil, err
+	}
+	return &namedRule{validator: c.Validator()}, nil
+}
+
+// compileRuleDependents compiles all the rules depending on the rule name
+// against the new rule r, since the compiled validator of the dependent rules
+// refers to the old one. But they are not registered.
+//
+// The dependent rules are compiled by a temporary child builder overlaying
+// the new rule and the compiled dependent rules, so the builder is not
+// changed if failing to compile any of them.
+func (b *Builder) compileRuleDependents(name string, r *namedRule) (map[string]*namedRule, error) {
+	overlay := NewBuilder()
+	overlay.parent = b
+	overlay.limits = b.getLimits()
+	if err := overlay.setRules(name, r, true, nil); err != nil {
+		return nil, err
+	}
+
+	dependents := make(map[string]*namedRule)
+	for _, dep := range b.ruleDependents(name) {
+		r := b.rules[dep]
+		node, _ := parseRule(r.rule) // The rule has been parsed successfully.
+		nr, err := overlay.compileRule(r.rule, node)
+		if err != nil {
+			return nil, NewRuleError(dep, r.rule, err)
+		}
+
+		nr.rule, nr.deps = r.rule, r.deps
+		if err = overlay.setRules(dep, nr, true, nil); err != nil {
+			return nil, err
+		}
+		dependents[dep] = nr
+	}
+	return dependents, nil
+}
+
+// ruleDependents returns the names of all the rules depending on the rule
+// name directly or indirectly, which is sorted in the topological order.
+func (b *Builder) ruleDependents(name string) (names []string) {
+	visited := make(map[string]bool)
+	var visit func(string) bool
+	visit = func(n string) (depend bool) {
+		if n == name {
+			return true
+		}
+		if d, ok := visited[n]; ok {
+			return d
+		}
+
+		visited[n] = false
+		for _, dep := range b.rules[n].deps {
+			if visit(dep) {
+				depend = true
+			}
+		}
+
+		if visited[n] = depend; depend {
+			names = append(names, n)
+		}
+		return
+	}
+
+	for n := range b.rules {
+		visit(n)
+	}
+	return
+}
+
+// findRuleCycle returns the path of the rule cycle if one of deps refers to
+// the rule name directly or indirectly. Or, return nil.
+func (b *Builder) findRuleCycle(name string, deps, path []string) []string {
+	path = append(path, name)
+	for _, dep := range deps {
+		if dep == path[0] {
+			return append(path, dep)
+		}
+
+		if r, ok := b.rules[dep]; ok {
+			if cycle := b.findRuleCycle(dep, r.deps, path); len(cycle) > 0 {
+				return cycle
+			}
+		}
+	}
+	return nil
+}
+
+// ruleDeps returns the names of the named rules referred to by the node,
+// including the rule self, which may have not been registered.
+func (b *Builder) ruleDeps(self string, node ruleNode) (deps []string) {
+	add := func(name string) {
+		if _, ok := b.rules[name]; !ok && name != self {
+			return
+		}
+		for _, dep := range deps {
+			if dep == name {
+				return
+			}
+		}
+		deps = append(deps, name)
+	}
+
+	var walk func(ruleNode)
+	walk = func(node ruleNode) {
+		switch n := node.(type) {
+		case *binaryNode:
+			walk(n.x)
+			walk(n.y)
+
+		case *notNode:
+			walk(n.x)
+
+		case *callNode:
+			add(n.name)
+			for _, arg := range n.args {
+				walk(arg)
+			}
+
+		case *identNode:
+			add(n.Name())
+
+		case *listNode:
+			for _, elem := range n.elems {
+				walk(elem)
+			}
+
+		case *kwargNode:
+			walk(n.value)
+		}
+	}
+
+	walk(node)
+	return
+}
+
+// isIdentifier reports whether the name is a valid identifier,
+// which may be a dotted name, such as "str.email".
+func isIdentifier(name string) bool {
+	for _, s := range strings.Split(name, ".") {
+		if !token.IsIdentifier(s) && (s == "" || !token.Lookup(s).IsKeyword()) {
+			return false
+		}
+	}
+	return true
+}
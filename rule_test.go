@@ -0,0 +1,154 @@
+// Copyright 2025 xgfone
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func ExampleBuilder_RegisterRule() {
+	builder := NewBuilder()
+	RegisterDefaultsForBuilder(builder)
+
+	err := builder.RegisterRule("username", `min(3) && max(32) && regexp("^[a-z0-9_]+$")`)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	const rule = `username && !oneof("admin", "root")`
+	fmt.Println(builder.Validate("xgfone", rule))
+	fmt.Println(builder.Validate("ab", rule))
+	fmt.Println(builder.Validate("root", rule))
+
+	for _, name := range builder.ValidatorNames() {
+		if strings.HasPrefix(name, "username") {
+			fmt.Println(name)
+		}
+	}
+
+	// Output:
+	// <nil>
+	// the string length is less than 3
+	// the value must not satisfy the rule oneof("admin", "root")
+	// username => min(3) && max(32) && regexp("^[a-z0-9_]+$")
+}
+
+func TestRegisterRule(t *testing.T) {
+	builder := NewBuilder()
+	RegisterDefaultsForBuilder(builder)
+
+	if err := builder.RegisterRule("a", "min(3)"); err != nil {
+		t.Fatal(err)
+	}
+	if err := builder.RegisterRule("b", "a && max(5)"); err != nil {
+		t.Fatal(err)
+	}
+	if err := builder.RegisterRule("c", "array(b)"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := builder.Validate([]string{"abc", "abcd"}, "c"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := builder.Validate([]string{"ab"}, "c"); err == nil {
+		t.Errorf("expect an error, but got nil")
+	}
+
+	// Redefine the rule, and the dependent rules should be rebuilt.
+	if err := builder.RegisterRule("a", "min(1)"); err != nil {
+		t.Fatal(err)
+	}
+	if err := builder.Validate([]string{"ab"}, "c"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	errs := map[string]string{
+		"a":       "rule cycle is detected: a -> a",
+		"c && a":  "rule cycle is detected: a -> c -> b -> a",
+		"min(1":   "invalid rule 'a': 1:6: expected ')', but got 'EOF'",
+		"unknown": "invalid rule 'a': 1:1: unknown is not defined",
+	}
+	for rule, expect := range errs {
+		if err := builder.RegisterRule("a", rule); err == nil {
+			t.Errorf("%s: expect an error, but got nil", rule)
+		} else if s := err.Error(); s != expect {
+			t.Errorf("%s: expect error '%s', but got '%s'", rule, expect, s)
+		}
+	}
+
+	if err := builder.RegisterRule("min", "max(1)"); err == nil {
+		t.Errorf("expect an error for the registered function, but got nil")
+	}
+	if err := builder.RegisterRule("a..b", "max(1)"); err == nil {
+		t.Errorf("expect an error for the invalid name, but got nil")
+	}
+	if err := builder.Build(NewContext(), "a(1)"); err == nil {
+		t.Errorf("expect an error for the arguments, but got nil")
+	}
+
+	if rules := builder.Rules(); len(rules) != 3 || rules["a"] != "min(1)" {
+		t.Errorf("unexpected rules: %v", rules)
+	}
+}
+
+func TestRegisterRuleAtomically(t *testing.T) {
+	builder := DefaultBuilder.Extend()
+	if err := builder.RegisterRule("a", "min(1)"); err != nil {
+		t.Fatal(err)
+	}
+	if err := builder.RegisterRule("b", `a && regexp("^[a-z]+$")`); err != nil {
+		t.Fatal(err)
+	}
+	if err := builder.RegisterRule("c", "array(b)"); err != nil {
+		t.Fatal(err)
+	}
+
+	// The dependent rule b cannot be rebuilt with the new limits.
+	builder.SetLimits(Limits{DenyFuncs: []string{"regexp"}})
+	version := builder.getVersion()
+	if err := builder.RegisterRule("a", "min(3)"); !errors.Is(err, ErrRuleLimit) {
+		t.Errorf("expect the error ErrRuleLimit, but got %v", err)
+	}
+
+	if rules := builder.Rules(); rules["a"] != "min(1)" {
+		t.Errorf("expect the old rule 'min(1)', but got '%s'", rules["a"])
+	}
+	if v := builder.getVersion(); v != version {
+		t.Errorf("expect the version %d, but got %d", version, v)
+	}
+	if err := builder.TryValidate([]string{"ab"}, "c"); !errors.Is(err, ErrRuleLimit) {
+		t.Errorf("expect the error ErrRuleLimit, but got %v", err)
+	}
+
+	builder.SetLimits(Limits{})
+	if err := builder.Validate([]string{"ab"}, "c"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	// Rebuild the dependent rules against the new rule.
+	if err := builder.RegisterRule("a", "min(3)"); err != nil {
+		t.Fatal(err)
+	}
+	if err := builder.Validate([]string{"ab"}, "c"); err == nil {
+		t.Errorf("expect an error, but got nil")
+	}
+	if err := builder.Validate([]string{"abc"}, "c"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
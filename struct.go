@@ -0,0 +1,158 @@
+// Copyright 2025 xgfone
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/xgfone/go-validation/internal"
+	"github.com/xgfone/go-validation/validator"
+)
+
+// StructTag is the name of the struct field tag that defines the validation rule.
+const StructTag = "validate"
+
+// omitemptyPrefix is the prefix of the field rule to skip the ZERO value,
+// such as `validate:"omitempty,min(1) && max(10)"`.
+const omitemptyPrefix = "omitempty,"
+
+// ValidateStruct is equal to DefaultBuilder.ValidateStruct(v).
+func ValidateStruct(v any) error {
+	return DefaultBuilder.ValidateStruct(v)
+}
+
+// ValidateStruct validates each exported field of the struct v
+// by the validation rule defined by the field tag "validate".
+//
+// If v is a pointer to struct, validate the struct that it points to.
+// If it is a nil pointer, do nothing and return nil.
+//
+// If the tag is empty or "-", the field is ignored. But the fields
+// of the embedded struct without the tag are validated as the promoted.
+// If the tag has the prefix "omitempty,", such as "omitempty,min(1)",
+// the field is not validated when it is ZERO, like the validator "optional".
+//
+// If a field is invalid, the returned error is a *validator.ValidationError
+// whose path starts with the field name, such as "Items[2].Name".
+// If the rule of a field is invalid, it is a *RuleError named by the field.
+//
+// When validating the fields, the struct is used as the parent of the
+// validation context, so the field rule can refer to its sibling fields,
+// such as eqfield("Password"), required_if("Type", "card"), etc.
+func (b *Builder) ValidateStruct(v any) error {
+	return b.validateStructContext(nil, v)
+}
+
+func (b *Builder) validateStructContext(c *validator.Context, v any) error {
+	vf := reflect.ValueOf(v)
+	for vf.Kind() == reflect.Ptr || vf.Kind() == reflect.Interface {
+		if vf.IsNil() {
+			return nil
+		}
+		vf = vf.Elem()
+	}
+
+	if vf.Kind() != reflect.Struct {
+		return fmt.Errorf("expect the value is a struct, but got %T", v)
+	}
+
+	var errs validator.Errors
+	b.validateStruct(c.WithParent(v), &errs, vf)
+	return errs.Err()
+}
+
+func (b *Builder) validateStruct(c *validator.Context, errs *validator.Errors, vf reflect.Value) (next bool) {
+	vt := vf.Type()
+	for i, _len := 0, vt.NumField(); i < _len; i++ {
+		field := vt.Field(i)
+		rule := field.Tag.Get(StructTag)
+
+		if field.Anonymous && rule == "" {
+			fv := vf.Field(i)
+			if fv.Kind() == reflect.Ptr {
+				if fv.IsNil() {
+					continue
+				}
+				fv = fv.Elem()
+			}
+
+			if fv.Kind() == reflect.Struct && !b.validateStruct(c, errs, fv) {
+				return false
+			}
+			continue
+		}
+
+		omitempty := strings.HasPrefix(rule, omitemptyPrefix)
+		if omitempty {
+			rule = strings.TrimSpace(rule[len(omitemptyPrefix):])
+		}
+
+		if !field.IsExported() || rule == "" || rule == "-" || rule == "omitempty" {
+			continue
+		}
+
+		fv := vf.Field(i)
+		if !fv.CanInterface() { // Promoted from the unexported embedded struct.
+			continue
+		}
+
+		value := fv.Interface()
+		if omitempty && internal.IsZero(value) {
+			continue
+		}
+
+		v, err := b.BuildValidator(rule)
+		if err != nil {
+			*errs = validator.AppendErrors(*errs, NewRuleError(field.Name, rule, err))
+			return false
+		}
+
+		err = validator.ValidateContext(c, v, value)
+		if !c.Collect(errs, validator.WrapError(err, field.Name, v.String(), value)) {
+			return false
+		}
+	}
+	return true
+}
+
+// newStructFunction returns the function "structure" to build the validator
+// validating the struct fields by the builder building the rule, which falls
+// back to b. So the child builder extended from b validates the fields by
+// the validators registered only on it.
+func newStructFunction(b *Builder) Function {
+	return newFunction("structure", []Param{}, func(c *Context, args ...any) error {
+		if len(args) > 0 {
+			return fmt.Errorf("structure must not have any arguments")
+		}
+
+		builder := c.Builder()
+		if builder == nil {
+			builder = b
+		}
+		c.AppendValidators(structValidator{b: builder})
+		return nil
+	})
+}
+
+// structValidator is the validator "structure" based on the struct tag.
+type structValidator struct{ b *Builder }
+
+func (v structValidator) String() string       { return "structure" }
+func (v structValidator) Validate(i any) error { return v.b.ValidateStruct(i) }
+func (v structValidator) ValidateContext(c *validator.Context, i any) error {
+	return v.b.validateStructContext(c, i)
+}
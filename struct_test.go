@@ -0,0 +1,182 @@
+// Copyright 2025 xgfone
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/xgfone/go-validation/validator"
+)
+
+type exampleAddress struct {
+	City string `validate:"required"`
+}
+
+type exampleBase struct {
+	ID int `validate:"min(1)"`
+}
+
+type exampleUser struct {
+	exampleBase
+	Name      string                    `validate:"min(3) && max(8)"`
+	Age       int                       `validate:"ranger(1, 120)"`
+	Addresses []exampleAddress          `validate:"array(structure)"`
+	Tags      map[string]exampleAddress `validate:"zero || mapv(structure)"`
+	Ignore    string                    `validate:"-"`
+	private   string                    `validate:"required"`
+}
+
+func ExampleValidateStruct() {
+	user := exampleUser{Name: "xgfone", Age: 18}
+	user.ID = 1
+	fmt.Println(ValidateStruct(user))
+
+	user.Name = "ab"
+	fmt.Println(ValidateStruct(&user))
+
+	user.Name = "abc"
+	user.Addresses = []exampleAddress{{City: "Beijing"}, {}}
+	fmt.Println(ValidateStruct(&user))
+
+	user.Addresses = nil
+	user.Tags = map[string]exampleAddress{"home": {}}
+	fmt.Println(ValidateStruct(&user))
+
+	user.Tags = nil
+	user.ID = 0
+	fmt.Println(ValidateStruct(&user))
+
+	fmt.Println(ValidateStruct((*exampleUser)(nil)))
+	fmt.Println(ValidateStruct(123))
+
+	// Output:
+	// <nil>
+	// Name: the string length is less than 3
+	// Addresses[1].City: the value cannot be empty
+	// Tags[home].City: the value cannot be empty
+	// ID: the integer is less than 1
+	// <nil>
+	// expect the value is a struct, but got int
+}
+
+func TestValidateStructCrossField(t *testing.T) {
+	type Payment struct {
+		Type     string `validate:"oneof(\"card\", \"cash\")"`
+		CardNo   string `validate:"required_if(\"Type\", \"card\")"`
+		Password string `validate:"required"`
+		Confirm  string `validate:"eqfield(\"Password\")"`
+		Min      int    `validate:"min(0)"`
+		Max      int    `validate:"gtefield(\"Min\")"`
+	}
+
+	type Order struct {
+		Payments []Payment `validate:"array(structure)"`
+	}
+
+	payment := Payment{Type: "cash", Password: "abc", Confirm: "abc", Min: 1, Max: 2}
+	if err := ValidateStruct(payment); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	order := Order{Payments: []Payment{payment, payment}}
+	order.Payments[1].Type = "card"
+	order.Payments[1].Confirm = "abd"
+	order.Payments[1].Max = 0
+
+	expects := []string{
+		"Payments[1].CardNo: the value cannot be empty when Type is card",
+		"Payments[1].Confirm: the value must be equal to the field Password",
+		"Payments[1].Max: the value must be greater than or equal to the field Min",
+	}
+
+	err := ValidateAll(order, "structure", 0)
+	if errs, ok := err.(validator.Errors); !ok {
+		t.Errorf("expect validator.Errors, but got %T: %v", err, err)
+	} else if len(errs) != len(expects) {
+		t.Errorf("expect %d errors, but got %d: %v", len(expects), len(errs), errs)
+	} else {
+		for i, err := range errs {
+			if s := err.Error(); s != expects[i] {
+				t.Errorf("%d: expect '%s', but got '%s'", i, expects[i], s)
+			}
+		}
+	}
+}
+
+func TestValidateStructOmitEmpty(t *testing.T) {
+	type Profile struct {
+		Email    string     `validate:"omitempty,regexp(\".+@.+\")"`
+		Age      *int       `validate:"omitempty, ranger(1, 120)"`
+		Birthday time.Time  `validate:"omitempty,within(876000h)"`
+		Website  string     `validate:"omitempty"`
+		Address  *struct{}  `validate:"omitempty,structure"`
+		Tags     []string   `validate:"omitempty,array(min(1))"`
+		Parent   *time.Time `validate:"omitempty,within(1h)"`
+	}
+
+	if err := ValidateStruct(Profile{}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	age := 0
+	err := ValidateStruct(Profile{Email: "abc", Age: &age})
+	if errs, ok := err.(validator.Errors); ok || err == nil {
+		t.Errorf("expect one error, but got %v", errs)
+	} else if s := err.Error(); s != "Email: invalid string for the regexp: ^.+@.+$" {
+		t.Errorf("unexpected error: %s", s)
+	}
+
+	err = ValidateStruct(Profile{Age: &age})
+	if err == nil || err.Error() != "Age: the integer is not in range [1, 120]" {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestValidateStructExtendedBuilder(t *testing.T) {
+	type Address struct {
+		Region string `validate:"region"`
+	}
+	type User struct {
+		Address   Address   `validate:"structure"`
+		Addresses []Address `validate:"array(structure)"`
+	}
+
+	child := DefaultBuilder.Extend()
+	child.RegisterValidatorOneof("region", "east", "west")
+
+	valid := User{Address: Address{Region: "east"}, Addresses: []Address{{Region: "west"}}}
+	if err := child.ValidateStruct(valid); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := child.TryValidate(valid.Address, "structure"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := child.TryValidate(valid.Addresses, "array(structure)"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	invalid := []Address{{Region: "north"}}
+	if err := child.TryValidate(invalid, "array(structure)"); err == nil {
+		t.Errorf("expect an error, but got nil")
+	} else if s := err.Error(); s != "[0].Region: the string 'north' is not one of [east west]" {
+		t.Errorf("unexpected error: %s", s)
+	}
+
+	if err := DefaultBuilder.TryValidate(valid.Address, "structure"); err == nil {
+		t.Errorf("expect an error, but got nil")
+	}
+}
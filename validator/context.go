@@ -0,0 +1,161 @@
+// Copyright 2025 xgfone
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validator
+
+// Context is the validation context passed through the validator tree
+// by the validators that have implemented the interface ContextValidator.
+//
+// A nil Context is valid, which is equal to the zero Context.
+type Context struct {
+	// All indicates whether to go on validating to collect all the errors
+	// instead of returning the first one.
+	All bool
+
+	// MaxErrors is the maximum number of the errors to be collected
+	// when All is true. 0 means no limit.
+	MaxErrors int
+
+	// Root is the top-level value to be validated, such as the outermost struct.
+	Root any
+
+	// Parent is the struct or map that contains the validated value directly,
+	// which is used to validate the value by referring to its sibling fields.
+	Parent any
+
+	// Vars is used to resolve the runtime variables referred to by the rule,
+	// such as "$quota" in "max($quota)".
+	Vars Vars
+}
+
+// Var returns the value of the runtime variable by the name.
+func (c *Context) Var(name string) (value any, ok bool) {
+	if c != nil && c.Vars != nil {
+		value, ok = c.Vars.Lookup(name)
+	}
+	return
+}
+
+// Vars is used to resolve the runtime variables when validating the value.
+type Vars interface {
+	Lookup(name string) (value any, ok bool)
+}
+
+// VarMap is a map implementing the interface Vars.
+type VarMap map[string]any
+
+// Lookup implements the interface Vars.
+func (m VarMap) Lookup(name string) (value any, ok bool) {
+	value, ok = m[name]
+	return
+}
+
+// VarFunc is a function implementing the interface Vars.
+type VarFunc func(name string) (value any, ok bool)
+
+// Lookup implements the interface Vars.
+func (f VarFunc) Lookup(name string) (value any, ok bool) { return f(name) }
+
+// WithParent returns a copy of the context with the new parent.
+//
+// If Root is nil, it is set to parent as well.
+func (c *Context) WithParent(parent any) *Context {
+	var nc Context
+	if c != nil {
+		nc = *c
+	}
+
+	nc.Parent = parent
+	if nc.Root == nil {
+		nc.Root = parent
+	}
+	return &nc
+}
+
+// CollectAll reports whether to collect all the errors.
+func (c *Context) CollectAll() bool { return c != nil && c.All }
+
+// Collect appends the error into errs and reports whether to go on validating.
+//
+// If err is nil, it always returns true. Or, it returns true only when
+// collecting all the errors and the number of the errors does not reach
+// MaxErrors.
+func (c *Context) Collect(errs *Errors, err error) (next bool) {
+	if err == nil {
+		return true
+	}
+
+	*errs = AppendErrors(*errs, err)
+	if !c.CollectAll() {
+		return false
+	}
+	return c.MaxErrors <= 0 || len(*errs) < c.MaxErrors
+}
+
+// ContextValidator is a validator to validate the value with the context.
+//
+// Notice: Validate should be equal to ValidateContext with a nil context.
+type ContextValidator interface {
+	Validator
+	ValidateContext(c *Context, value any) error
+}
+
+// NewContextValidator returns a new ContextValidator based on the validation
+// rule and function with the context.
+//
+// When calling its method Validate, the context is nil.
+func NewContextValidator(rule string, validate func(c *Context, value any) error) Validator {
+	return contextValidator{s: rule, f: validate}
+}
+
+type contextValidator struct {
+	s string
+	f func(*Context, any) error
+}
+
+func (v contextValidator) String() string                          { return v.s }
+func (v contextValidator) Validate(i any) error                    { return v.f(nil, i) }
+func (v contextValidator) ValidateContext(c *Context, i any) error { return v.f(c, i) }
+
+// ValidateContext validates the value by the validator v with the context c.
+//
+// If v has implemented the interface ContextValidator, call its method
+// ValidateContext. Or, call its method Validate.
+func ValidateContext(c *Context, v Validator, value any) error {
+	if c != nil {
+		if cv, ok := v.(ContextValidator); ok {
+			return cv.ValidateContext(c, value)
+		}
+	}
+	return v.Validate(value)
+}
+
+// ValidateAll validates the value by the validator v, but goes on validating
+// to collect all the errors instead of returning the first one.
+//
+// If maxErrors is greater than 0, collect at most maxErrors errors.
+//
+// If the value is invalid, the returned error is Errors. Or, return nil.
+func ValidateAll(v Validator, value any, maxErrors int) error {
+	c := &Context{All: true, MaxErrors: maxErrors}
+	errs := AppendErrors(nil, ValidateContext(c, v, value))
+	if len(errs) == 0 {
+		return nil
+	}
+
+	if maxErrors > 0 && len(errs) > maxErrors {
+		errs = errs[:maxErrors]
+	}
+	return errs
+}
@@ -0,0 +1,84 @@
+// Copyright 2025 xgfone
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validator
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValidateAll(t *testing.T) {
+	err1 := errors.New("err1")
+	err2 := errors.New("err2")
+	err3 := errors.New("err3")
+	fail := func(err error) Validator {
+		return NewValidator(err.Error(), func(any) error { return err })
+	}
+	pass := NewValidator("pass", func(any) error { return nil })
+
+	and := And(fail(err1), pass, Or(fail(err2), fail(err3)), fail(err2))
+	if err := and.Validate(nil); err != err1 {
+		t.Errorf("expect error '%v', but got '%v'", err1, err)
+	}
+
+	err := ValidateAll(and, nil, 0)
+	if errs, ok := err.(Errors); !ok {
+		t.Errorf("expect Errors, but got %T", err)
+	} else if len(errs) != 3 || errs[0] != err1 || errs[1] != err3 || errs[2] != err2 {
+		t.Errorf("unexpected errors: %v", errs)
+	} else if s := errs.Error(); s != "err1; err3; err2" {
+		t.Errorf("expect error message '%s', but got '%s'", "err1; err3; err2", s)
+	}
+
+	if !errors.Is(err, err3) {
+		t.Errorf("expect that the errors contains err3")
+	}
+
+	err = ValidateAll(and, nil, 2)
+	if errs, ok := err.(Errors); !ok {
+		t.Errorf("expect Errors, but got %T", err)
+	} else if len(errs) != 2 {
+		t.Errorf("expect 2 errors, but got %d: %v", len(errs), errs)
+	}
+
+	if err := ValidateAll(pass, nil, 0); err != nil {
+		t.Errorf("expect nil, but got an error: %v", err)
+	}
+
+	if err := ValidateAll(fail(err1), nil, 0); !errors.Is(err, err1) {
+		t.Errorf("expect error '%v', but got '%v'", err1, err)
+	} else if _, ok := err.(Errors); !ok {
+		t.Errorf("expect Errors, but got %T", err)
+	}
+}
+
+func TestContextVar(t *testing.T) {
+	var c *Context
+	if _, ok := c.Var("a"); ok {
+		t.Errorf("expect no variable for the nil context")
+	}
+
+	c = &Context{Vars: VarMap{"a": 1}}
+	if v, ok := c.WithParent(nil).Var("a"); !ok || v != 1 {
+		t.Errorf("expect variable 1, but got %v", v)
+	}
+
+	c.Vars = VarFunc(func(name string) (any, bool) { return name, name == "b" })
+	if _, ok := c.Var("a"); ok {
+		t.Errorf("unexpected variable 'a'")
+	} else if v, _ := c.Var("b"); v != "b" {
+		t.Errorf("expect variable 'b', but got %v", v)
+	}
+}
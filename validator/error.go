@@ -0,0 +1,188 @@
+// Copyright 2025 xgfone
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ValidationError represents a validation error of a field or an element,
+// which can be extracted from the returned error by errors.As.
+type ValidationError struct {
+	// Path is the path of the invalid field or element,
+	// such as "name", "[2]", "items[2].name", etc.
+	//
+	// It is empty if the validated value is not in a container.
+	Path string
+
+	// Rule is the rule of the failing validator, that's, Validator.String().
+	Rule string
+
+	// Value is the invalid value.
+	Value any
+
+	// Err is the original error returned by the failing validator.
+	Err error
+}
+
+// NewValidationError returns a new ValidationError.
+func NewValidationError(path, rule string, value any, err error) *ValidationError {
+	return &ValidationError{Path: path, Rule: rule, Value: value, Err: err}
+}
+
+// Message returns the error message without the path.
+func (e *ValidationError) Message() string {
+	if e.Err == nil {
+		return "invalid value"
+	}
+	return e.Err.Error()
+}
+
+// Error implements the interface error.
+func (e *ValidationError) Error() string {
+	if e.Path == "" {
+		return e.Message()
+	}
+	return fmt.Sprintf("%s: %s", e.Path, e.Message())
+}
+
+// Unwrap returns the original error.
+func (e *ValidationError) Unwrap() error { return e.Err }
+
+// WrapError wraps the error returned by the validator with the rule
+// to validate the value located at the path.
+//
+// If err is or wraps a ValidationError, which is found by errors.As,
+// it returns a copy of the ValidationError with the path prepended by path,
+// and keeps its rule and value unchanged. So the errors wrapping it,
+// such as fmt.Errorf("...: %w", err), are discarded.
+// Or, it returns a new ValidationError.
+//
+// If err is Errors, wrap each of them and return a new Errors.
+//
+// If err is nil, return nil.
+func WrapError(err error, path, rule string, value any) error {
+	switch e := err.(type) {
+	case nil:
+		return nil
+
+	case Errors:
+		errs := make(Errors, len(e))
+		for i, err := range e {
+			errs[i] = WrapError(err, path, rule, value)
+		}
+		return errs
+	}
+
+	var ve *ValidationError
+	if errors.As(err, &ve) {
+		e := *ve
+		e.Path = JoinPath(path, ve.Path)
+		return &e
+	}
+	return NewValidationError(path, rule, value, err)
+}
+
+// JoinPath joins the parent path and the child path, for example,
+//
+//	JoinPath("", "name")        => "name"
+//	JoinPath("items", "[2]")    => "items[2]"
+//	JoinPath("items[2]", "name") => "items[2].name"
+func JoinPath(parent, child string) string {
+	switch {
+	case parent == "":
+		return child
+	case child == "":
+		return parent
+	case child[0] == '[':
+		return parent + child
+	default:
+		return parent + "." + child
+	}
+}
+
+// Errors represents a set of errors, which is returned when collecting
+// all the errors. See ValidateAll.
+type Errors []error
+
+// AppendErrors appends the error into errs and returns the new Errors.
+//
+// If err is nil, do nothing. If err is Errors, append all of them.
+func AppendErrors(errs Errors, err error) Errors {
+	switch e := err.(type) {
+	case nil:
+		return errs
+	case Errors:
+		return append(errs, e...)
+	default:
+		return append(errs, err)
+	}
+}
+
+// Err returns nil if errs is empty, the only error if there is only one,
+// or errs itself.
+func (es Errors) Err() error {
+	switch len(es) {
+	case 0:
+		return nil
+	case 1:
+		return es[0]
+	default:
+		return es
+	}
+}
+
+// Error implements the interface error, which joins the messages of all
+// the errors with "; ".
+func (es Errors) Error() string {
+	switch len(es) {
+	case 0:
+		return ""
+	case 1:
+		return es[0].Error()
+	}
+
+	var b strings.Builder
+	b.Grow(64)
+	for i, err := range es {
+		if i > 0 {
+			b.WriteString("; ")
+		}
+		b.WriteString(err.Error())
+	}
+	return b.String()
+}
+
+// Is reports whether any error in es matches target, which is used by errors.Is.
+func (es Errors) Is(target error) bool {
+	for _, err := range es {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// As finds the first error in es that matches target, which is used by errors.As.
+func (es Errors) As(target any) bool {
+	for _, err := range es {
+		if errors.As(err, target) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,91 @@
+// Copyright 2025 xgfone
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestJoinPath(t *testing.T) {
+	tests := []struct{ parent, child, expect string }{
+		{"", "", ""},
+		{"", "name", "name"},
+		{"items", "", "items"},
+		{"items", "[2]", "items[2]"},
+		{"items[2]", "name", "items[2].name"},
+		{"[1]", "[2]", "[1][2]"},
+	}
+
+	for _, test := range tests {
+		if path := JoinPath(test.parent, test.child); path != test.expect {
+			t.Errorf("expect path '%s', but got '%s'", test.expect, path)
+		}
+	}
+}
+
+func TestWrapError(t *testing.T) {
+	if err := WrapError(nil, "name", "min(1)", ""); err != nil {
+		t.Errorf("expect nil, but got an error: %v", err)
+	}
+
+	errMin := errors.New("the string length is less than 1")
+	err := WrapError(errMin, "name", "min(1)", "")
+	err = WrapError(err, "[2]", "structure", struct{}{})
+	err = WrapError(err, "items", "array(structure)", []struct{}{})
+
+	const expect = "items[2].name: the string length is less than 1"
+	if s := err.Error(); s != expect {
+		t.Errorf("expect error '%s', but got '%s'", expect, s)
+	}
+
+	var ve *ValidationError
+	if !errors.As(err, &ve) {
+		t.Fatalf("expect a ValidationError, but got %T", err)
+	}
+	if ve.Path != "items[2].name" {
+		t.Errorf("expect path '%s', but got '%s'", "items[2].name", ve.Path)
+	}
+	if ve.Rule != "min(1)" {
+		t.Errorf("expect rule '%s', but got '%s'", "min(1)", ve.Rule)
+	}
+	if ve.Value != "" {
+		t.Errorf("expect an empty string value, but got '%v'", ve.Value)
+	}
+	if !errors.Is(err, errMin) {
+		t.Errorf("expect the original error is wrapped")
+	}
+}
+
+func TestWrapErrorWrapped(t *testing.T) {
+	errMin := errors.New("the string length is less than 1")
+	err := fmt.Errorf("name: %w", WrapError(errMin, "name", "min(1)", ""))
+	err = WrapError(err, "[2]", "structure", struct{}{})
+
+	var ve *ValidationError
+	if !errors.As(err, &ve) {
+		t.Fatalf("expect a ValidationError, but got %T", err)
+	}
+	if ve.Path != "[2].name" {
+		t.Errorf("expect path '%s', but got '%s'", "[2].name", ve.Path)
+	}
+	if ve.Rule != "min(1)" {
+		t.Errorf("expect rule '%s', but got '%s'", "min(1)", ve.Rule)
+	}
+	if ve.Err != errMin {
+		t.Errorf("expect the original error '%v', but got '%v'", errMin, ve.Err)
+	}
+}
@@ -0,0 +1,106 @@
+// Copyright 2025 xgfone
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validator
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+)
+
+// Report is the evaluation report of a node of the validator tree.
+type Report struct {
+	Rule     string    `json:"rule"`
+	Valid    bool      `json:"valid"`
+	Error    string    `json:"error,omitempty"`
+	Children []*Report `json:"children,omitempty"`
+
+	// Err is the original error returned by the validator of the node.
+	Err error `json:"-"`
+}
+
+// Explain is equal to ExplainContext(nil, v, value).
+func Explain(v Validator, value any) *Report {
+	return ExplainContext(nil, v, value)
+}
+
+// ExplainContext validates the value by the validator v with the context c,
+// and evaluates every node of the validator tree, including all the branches
+// of OR which would be skipped by Validate, to report why it is valid or not.
+//
+// The children are walked only for the nodes validating the same value,
+// that's, the kinds of KindAnd, KindOr, KindNot, KindIf and KindOptional.
+//
+// Notice: every node is validated once by itself and once more by each of
+// its ancestors, so the cost is quadratic in the depth of the validator tree.
+// It is used to debug the rule, not to validate the value in the hot path.
+func ExplainContext(c *Context, v Validator, value any) *Report {
+	err := ValidateContext(c, v, value)
+	r := &Report{Rule: v.String(), Valid: err == nil, Err: err}
+	if err != nil {
+		r.Error = err.Error()
+	}
+
+	switch n := Inspect(v); n.Kind() {
+	case KindAnd, KindOr, KindNot, KindIf, KindOptional:
+		validators := n.Children()
+		r.Children = make([]*Report, len(validators))
+		for i, v := range validators {
+			r.Children[i] = ExplainContext(c, v, value)
+		}
+	}
+
+	return r
+}
+
+// String returns the indented text report, such as
+//
+//	FAIL (min(3) || zero): the string length is less than 3
+//	  FAIL min(3): the string length is less than 3
+//	  FAIL zero: the value should be empty
+func (r *Report) String() string {
+	var b strings.Builder
+	r.writeText(&b, 0)
+	return b.String()
+}
+
+func (r *Report) writeText(b *strings.Builder, depth int) {
+	for i := 0; i < depth; i++ {
+		b.WriteString("  ")
+	}
+
+	if r.Valid {
+		b.WriteString("PASS ")
+		b.WriteString(r.Rule)
+	} else {
+		b.WriteString("FAIL ")
+		b.WriteString(r.Rule)
+		b.WriteString(": ")
+		b.WriteString(r.Error)
+	}
+	b.WriteByte('\n')
+
+	for _, child := range r.Children {
+		child.writeText(b, depth+1)
+	}
+}
+
+// WriteJSON writes the report into w as the indented JSON.
+func (r *Report) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(r)
+}
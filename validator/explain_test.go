@@ -0,0 +1,74 @@
+// Copyright 2025 xgfone
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validator
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func newTestValidator(rule string, valid bool) Validator {
+	return NewValidator(rule, func(any) error {
+		if valid {
+			return nil
+		}
+		return errors.New(rule + " fails")
+	})
+}
+
+func ExampleExplain() {
+	a := newTestValidator("a", false)
+	b := newTestValidator("b", true)
+	c := newTestValidator("c", false)
+	v := And(b, Or(a, c), Not(a, nil))
+
+	fmt.Print(Explain(v, nil))
+
+	// Output:
+	// FAIL (b && (a || c) && !a): c fails
+	//   PASS b
+	//   FAIL (a || c): c fails
+	//     FAIL a: a fails
+	//     FAIL c: c fails
+	//   PASS !a
+	//     FAIL a: a fails
+}
+
+func TestExplain(t *testing.T) {
+	a := newTestValidator("a", true)
+	b := newTestValidator("b", false)
+	r := Explain(If(a, Optional(b), nil), 1)
+	if r.Valid || r.Err == nil || len(r.Children) != 2 {
+		t.Fatalf("unexpected report: %s", r)
+	}
+	if c := r.Children[1]; c.Rule != "optional(b)" || len(c.Children) != 1 || c.Children[0].Error != "b fails" {
+		t.Errorf("unexpected report: %s", c)
+	}
+
+	buf := bytes.NewBuffer(nil)
+	if err := r.WriteJSON(buf); err != nil {
+		t.Fatal(err)
+	}
+
+	var report Report
+	if err := json.Unmarshal(buf.Bytes(), &report); err != nil {
+		t.Fatal(err)
+	} else if report.String() != r.String() {
+		t.Errorf("expect report '%s', but got '%s'", r, &report)
+	}
+}
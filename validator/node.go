@@ -0,0 +1,140 @@
+// Copyright 2025 xgfone
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validator
+
+// Kind is the kind of the node of the validator tree.
+type Kind string
+
+// Predefine the kinds of the validator node.
+const (
+	KindLeaf     Kind = "leaf"     // Without the sub-validators, such as min(1).
+	KindAnd      Kind = "and"      // All the children, such as a && b.
+	KindOr       Kind = "or"       // Any of the children, such as a || b.
+	KindNot      Kind = "not"      // Not the only child, such as !a.
+	KindIf       Kind = "if"       // The children are the condition, then and else.
+	KindOptional Kind = "optional" // The only child if the value is not ZERO.
+	KindEach     Kind = "each"     // The children for each element of the container, such as array(a).
+)
+
+// Node is the introspection interface of the validator tree,
+// which is implemented by all the built-in validators, so the tooling,
+// such as the optimizer and exporter, can walk the compiled validator
+// instead of re-parsing the rule.
+type Node interface {
+	Validator
+
+	// Kind returns the kind of the node.
+	Kind() Kind
+
+	// Name returns the function name of the node, such as "min", "oneof",
+	// "len", "and", "or", "not", etc.
+	Name() string
+
+	// Args returns the arguments of the node except the sub-validators,
+	// such as [1] for "min(1)", [">=", 3] for "len >= 3".
+	Args() []any
+
+	// Children returns the sub-validators of the node.
+	Children() []Validator
+}
+
+// Leaf returns a new leaf Validator wrapping v, which implements
+// the interface Node with the function name and arguments of its rule.
+//
+// If v has implemented the interface ContextValidator, so does it.
+func Leaf(v Validator, name string, args ...any) Validator {
+	if v == nil {
+		panic("LeafValidator: the validator must not be nil")
+	}
+	return leafValidator{Validator: v, name: name, args: args}
+}
+
+type leafValidator struct {
+	Validator
+	name string
+	args []any
+}
+
+func (v leafValidator) Kind() Kind            { return KindLeaf }
+func (v leafValidator) Name() string          { return v.name }
+func (v leafValidator) Args() []any           { return append([]any(nil), v.args...) }
+func (v leafValidator) Children() []Validator { return nil }
+
+func (v leafValidator) ValidateContext(c *Context, i any) error {
+	return ValidateContext(c, v.Validator, i)
+}
+
+// Inspect returns the Node of the validator v.
+//
+// If v does not implement the interface Node, return a leaf node
+// named by the rule of v without the arguments.
+func Inspect(v Validator) Node {
+	if n, ok := v.(Node); ok {
+		return n
+	}
+	return leafValidator{Validator: v, name: v.String()}
+}
+
+// Walk walks the validator tree in depth-first order, which calls visit
+// for each node and walks its children only if visit returns true.
+func Walk(v Validator, visit func(Node) bool) {
+	n := Inspect(v)
+	if visit(n) {
+		for _, child := range n.Children() {
+			Walk(child, visit)
+		}
+	}
+}
+
+func (vs andValidator) Kind() Kind            { return KindAnd }
+func (vs andValidator) Name() string          { return string(KindAnd) }
+func (vs andValidator) Args() []any           { return nil }
+func (vs andValidator) Children() []Validator { return append([]Validator(nil), vs...) }
+
+func (vs orValidator) Kind() Kind            { return KindOr }
+func (vs orValidator) Name() string          { return string(KindOr) }
+func (vs orValidator) Args() []any           { return nil }
+func (vs orValidator) Children() []Validator { return append([]Validator(nil), vs...) }
+
+func (v notValidator) Kind() Kind            { return KindNot }
+func (v notValidator) Name() string          { return string(KindNot) }
+func (v notValidator) Args() []any           { return nil }
+func (v notValidator) Children() []Validator { return []Validator{v.validator} }
+
+// NotError returns the error returned by the NOT validator v
+// when its child succeeds, which is built by Not.
+//
+// If v is not a NOT validator, return nil.
+func NotError(v Validator) error {
+	if n, ok := v.(notValidator); ok {
+		return n.err
+	}
+	return nil
+}
+
+func (v optionalValidator) Kind() Kind            { return KindOptional }
+func (v optionalValidator) Name() string          { return string(KindOptional) }
+func (v optionalValidator) Args() []any           { return nil }
+func (v optionalValidator) Children() []Validator { return []Validator{v.validator} }
+
+func (v ifValidator) Kind() Kind   { return KindIf }
+func (v ifValidator) Name() string { return string(KindIf) }
+func (v ifValidator) Args() []any  { return nil }
+func (v ifValidator) Children() []Validator {
+	if v.otherwise == nil {
+		return []Validator{v.cond, v.then}
+	}
+	return []Validator{v.cond, v.then, v.otherwise}
+}
@@ -0,0 +1,63 @@
+// Copyright 2025 xgfone
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func ExampleWalk() {
+	min := Leaf(NewValidator("min(1)", nil), "min", 1)
+	max := Leaf(NewValidator("max(9)", nil), "max", 9)
+	zero := NewValidator("zero", nil)
+	v := Or(zero, And(min, Not(max, nil)), If(zero, Optional(min), nil))
+
+	Walk(v, func(n Node) bool {
+		fmt.Printf("%s: name=%s, args=%v, children=%d\n", n.Kind(), n.Name(), n.Args(), len(n.Children()))
+		return n.Kind() != KindNot // Not walk the children of NOT.
+	})
+
+	// Output:
+	// or: name=or, args=[], children=3
+	// leaf: name=zero, args=[], children=0
+	// and: name=and, args=[], children=2
+	// leaf: name=min, args=[1], children=0
+	// not: name=not, args=[], children=1
+	// if: name=if, args=[], children=2
+	// leaf: name=zero, args=[], children=0
+	// optional: name=optional, args=[], children=1
+	// leaf: name=min, args=[1], children=0
+}
+
+func TestLeafContext(t *testing.T) {
+	v := Leaf(NewContextValidator("parent", func(c *Context, _ any) error {
+		if c == nil || c.Parent == nil {
+			return errors.New("no parent")
+		}
+		return nil
+	}), "parent")
+
+	if err := ValidateContext(&Context{Parent: 1}, v, nil); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := v.Validate(nil); err == nil {
+		t.Errorf("expect an error, but got nil")
+	}
+	if n := Inspect(NewValidator("zero", nil)); n.Kind() != KindLeaf || n.Name() != "zero" {
+		t.Errorf("unexpected node: %s %s", n.Kind(), n.Name())
+	}
+}
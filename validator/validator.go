@@ -19,6 +19,9 @@ import (
 	"fmt"
 	"reflect"
 	"strings"
+	"unicode"
+
+	"github.com/xgfone/go-validation/internal"
 )
 
 // ValueValidator represents the interface implemented by the value.
@@ -205,6 +208,20 @@ func (vs andValidator) Validate(v any) (err error) {
 	return
 }
 
+// ValidateContext implements the interface ContextValidator.
+//
+// If collecting all the errors, it goes on validating the value
+// by the rest validators after one fails.
+func (vs andValidator) ValidateContext(c *Context, v any) error {
+	var errs Errors
+	for i, _len := 0, len(vs); i < _len; i++ {
+		if !c.Collect(&errs, ValidateContext(c, vs[i], v)) {
+			break
+		}
+	}
+	return errs.Err()
+}
+
 func (vs andValidator) String() string {
 	return formatValidators(" && ", []Validator(vs))
 }
@@ -245,6 +262,24 @@ func (vs orValidator) Validate(v any) (err error) {
 	return
 }
 
+// ValidateContext implements the interface ContextValidator.
+//
+// Even if collecting all the errors, it only returns the error
+// of the last validator when all the validators fail.
+func (vs orValidator) ValidateContext(c *Context, v any) (err error) {
+	var oc Context
+	if c != nil {
+		oc = *c
+		oc.All = false
+	}
+	for i, _len := 0, len(vs); i < _len; i++ {
+		if err = ValidateContext(&oc, vs[i], v); err == nil {
+			return nil
+		}
+	}
+	return
+}
+
 func (vs orValidator) String() string {
 	return formatValidators(" || ", []Validator(vs))
 }
@@ -269,3 +304,203 @@ func Or(validators ...Validator) Validator {
 
 	return orValidator(vs)
 }
+
+// ************************************************************************* //
+
+// notValidator is a NOT validator based on a validator.
+type notValidator struct {
+	validator Validator
+	err       error
+}
+
+// Validate implements the interface Validator.
+func (v notValidator) Validate(i any) error {
+	if v.validator.Validate(i) == nil {
+		return v.err
+	}
+	return nil
+}
+
+// ValidateContext implements the interface ContextValidator.
+func (v notValidator) ValidateContext(c *Context, i any) error {
+	var nc Context
+	if c != nil {
+		nc = *c
+		nc.All = false
+	}
+
+	if ValidateContext(&nc, v.validator, i) == nil {
+		return v.err
+	}
+	return nil
+}
+
+func (v notValidator) String() string {
+	s := v.validator.String()
+	switch Inspect(v.validator).Kind() {
+	case KindAnd, KindOr: // Have been enclosed by the parentheses.
+		return "!" + s
+	case KindNot:
+		return "!" + s
+	default:
+		if isCallRule(s) {
+			return "!" + s
+		}
+		return "!(" + s + ")"
+	}
+}
+
+// Not returns a new NOT Validator, which returns the error err
+// if the validator v succeeds, or nil if it fails.
+//
+// If err is nil, use the default error derived from v.String().
+func Not(v Validator, err error) Validator {
+	if v == nil {
+		panic("NotValidator: the validator must not be nil")
+	}
+
+	if err == nil {
+		err = fmt.Errorf("the value must not satisfy the rule %s", v.String())
+	}
+	return notValidator{validator: v, err: err}
+}
+
+// ************************************************************************* //
+
+// ifValidator is a conditional validator.
+type ifValidator struct {
+	cond      Validator
+	then      Validator
+	otherwise Validator // nil means passing.
+}
+
+// Validate implements the interface Validator.
+func (v ifValidator) Validate(i any) error {
+	return v.ValidateContext(nil, i)
+}
+
+// ValidateContext implements the interface ContextValidator.
+//
+// The condition is always validated without collecting all the errors.
+func (v ifValidator) ValidateContext(c *Context, i any) error {
+	var cc Context
+	if c != nil {
+		cc = *c
+		cc.All = false
+	}
+
+	switch {
+	case ValidateContext(&cc, v.cond, i) == nil:
+		return ValidateContext(c, v.then, i)
+	case v.otherwise != nil:
+		return ValidateContext(c, v.otherwise, i)
+	default:
+		return nil
+	}
+}
+
+func (v ifValidator) String() string {
+	if v.otherwise == nil {
+		return fmt.Sprintf("if(%s, %s)", v.cond.String(), v.then.String())
+	}
+	return fmt.Sprintf("if(%s, %s, %s)", v.cond.String(), v.then.String(), v.otherwise.String())
+}
+
+// If returns a new conditional Validator, which validates the value by then
+// if the value satisfies cond, or by otherwise if not.
+//
+// If otherwise is nil, the value is valid when not satisfying cond.
+// And the error of cond is never returned.
+func If(cond, then, otherwise Validator) Validator {
+	if cond == nil || then == nil {
+		panic("IfValidator: the condition and then validators must not be nil")
+	}
+	return ifValidator{cond: cond, then: then, otherwise: otherwise}
+}
+
+// ************************************************************************* //
+
+// optionalValidator is a validator skipping the ZERO value.
+type optionalValidator struct {
+	validator Validator
+}
+
+// Validate implements the interface Validator.
+func (v optionalValidator) Validate(i any) error {
+	if internal.IsZero(i) {
+		return nil
+	}
+	return v.validator.Validate(i)
+}
+
+// ValidateContext implements the interface ContextValidator.
+func (v optionalValidator) ValidateContext(c *Context, i any) error {
+	if internal.IsZero(i) {
+		return nil
+	}
+	return ValidateContext(c, v.validator, i)
+}
+
+func (v optionalValidator) String() string {
+	s := v.validator.String()
+	switch Inspect(v.validator).Kind() {
+	case KindAnd, KindOr: // Remove the redundant parentheses.
+		return "optional" + s
+	default:
+		return "optional(" + s + ")"
+	}
+}
+
+// Optional returns a new Validator, which skips the validation if the value
+// is ZERO, such as nil, a nil pointer, a zero value, or a value whose method
+// IsZero returns true. Or, return the error of v unchanged.
+//
+// It is used instead of "zero || v" whose error comes from the OR branch.
+func Optional(v Validator) Validator {
+	if v == nil {
+		panic("OptionalValidator: the validator must not be nil")
+	}
+	return optionalValidator{validator: v}
+}
+
+// isCallRule reports whether the rule is an identifier or a function call,
+// such as "zero" and `oneof("a", "b")`, the parentheses of which enclose
+// all the arguments, so it does not need to be enclosed by the parentheses
+// when following the operator NOT.
+func isCallRule(s string) bool {
+	i := strings.IndexFunc(s, func(r rune) bool {
+		return r != '_' && r != '.' && !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	switch {
+	case i < 0: // identifier
+		return s != ""
+	case i == 0 || s[i] != '(':
+		return false
+	}
+
+	var depth int
+	var quote byte
+	for j := i; j < len(s); j++ {
+		switch c := s[j]; {
+		case quote != 0:
+			if c == '\\' && quote == '"' {
+				j++
+			} else if c == quote {
+				quote = 0
+			}
+
+		case c == '"' || c == '`':
+			quote = c
+
+		case c == '(':
+			depth++
+
+		case c == ')':
+			if depth--; depth == 0 {
+				return j == len(s)-1
+			}
+		}
+	}
+	return false
+}
@@ -52,3 +52,104 @@ func TestAnd(t *testing.T) {
 		t.Error(err)
 	}
 }
+
+func TestNot(t *testing.T) {
+	isabc := NewValidator(`isabc`, BoolValidateFunc(func(s string) bool { return s == "abc" }, errors.New("test")))
+
+	tests := []struct {
+		validator Validator
+		expect    string
+	}{
+		{Not(isabc, nil), "!isabc"},
+		{Not(Not(isabc, nil), nil), "!!isabc"},
+		{Not(NewValidator(`oneof("a", "b")`, nil), nil), `!oneof("a", "b")`},
+		{Not(And(isabc, isabc), nil), "!(isabc && isabc)"},
+		{Not(NewValidator("len >= 3", nil), nil), "!(len >= 3)"},
+		{Not(NewValidator("(a) || (b)", nil), nil), "!((a) || (b))"},
+		{Not(NewValidator("f(x) || g(y)", nil), nil), "!(f(x) || g(y))"},
+		{Not(NewValidator(`f(")") || g("(")`, nil), nil), `!(f(")") || g("("))`},
+		{Not(NewValidator(`oneof(")", "(")`, nil), nil), `!oneof(")", "(")`},
+		{Not(Optional(isabc), nil), "!optional(isabc)"},
+	}
+	for _, test := range tests {
+		if s := test.validator.String(); s != test.expect {
+			t.Errorf("expect %s, but got %s", test.expect, s)
+		}
+	}
+
+	validator := Not(isabc, nil)
+	if err := validator.Validate("xyz"); err != nil {
+		t.Error(err)
+	}
+	if err := validator.Validate("abc"); err == nil {
+		t.Errorf("expect an error, but got nil")
+	} else if s := err.Error(); s != "the value must not satisfy the rule isabc" {
+		t.Errorf("unexpected error: %s", s)
+	}
+
+	errNot := errors.New("not abc")
+	if err := Not(isabc, errNot).Validate("abc"); err != errNot {
+		t.Errorf("expect error '%v', but got '%v'", errNot, err)
+	}
+}
+
+func TestIf(t *testing.T) {
+	errCond := errors.New("not string")
+	errThen := errors.New("then")
+	errElse := errors.New("else")
+	isstr := NewValidator("isstr", func(i any) error {
+		if _, ok := i.(string); !ok {
+			return errCond
+		}
+		return nil
+	})
+	fail := func(err error) Validator {
+		return NewValidator(err.Error(), func(any) error { return err })
+	}
+
+	v := If(isstr, fail(errThen), fail(errElse))
+	if s := v.String(); s != "if(isstr, then, else)" {
+		t.Errorf("unexpected rule '%s'", s)
+	}
+	if err := v.Validate("abc"); err != errThen {
+		t.Errorf("expect error '%v', but got '%v'", errThen, err)
+	}
+	if err := v.Validate(123); err != errElse {
+		t.Errorf("expect error '%v', but got '%v'", errElse, err)
+	}
+
+	v = If(isstr, fail(errThen), nil)
+	if s := v.String(); s != "if(isstr, then)" {
+		t.Errorf("unexpected rule '%s'", s)
+	}
+	if err := v.Validate(123); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	v = If(isstr, And(fail(errThen), fail(errElse)), nil)
+	if errs, ok := ValidateAll(v, "abc", 0).(Errors); !ok || len(errs) != 2 {
+		t.Errorf("expect two errors, but got %v", errs)
+	}
+}
+
+func TestOptional(t *testing.T) {
+	errFail := errors.New("fail")
+	v := Optional(NewValidator("fail", func(any) error { return errFail }))
+	if s := v.String(); s != "optional(fail)" {
+		t.Errorf("unexpected rule '%s'", s)
+	}
+
+	var p *int
+	for _, value := range []any{nil, 0, "", p} {
+		if err := v.Validate(value); err != nil {
+			t.Errorf("%v: unexpected error: %v", value, err)
+		}
+		if err := ValidateContext(&Context{All: true}, v, value); err != nil {
+			t.Errorf("%v: unexpected error: %v", value, err)
+		}
+	}
+
+	if err := v.Validate(1); err != errFail {
+		t.Errorf("expect error '%v', but got '%v'", errFail, err)
+	}
+}
@@ -31,34 +31,60 @@ func Array(validators ...validator.Validator) validator.Validator {
 	}
 
 	_validator, desc := composeValidators("array", validators...)
-	return validator.NewValidator(desc, func(i any) error {
-		switch vs := i.(type) {
-		case []string:
-			for i, s := range vs {
-				if err := _validator.Validate(s); err != nil {
-					return fmt.Errorf("%dth element is invalid: %v", i, err)
-				}
+	return arrayValidator{desc: desc, rule: _validator.String(), validator: _validator, validators: validators}
+}
+
+type arrayValidator struct {
+	desc       string
+	rule       string
+	validator  validator.Validator
+	validators []validator.Validator
+}
+
+func (v arrayValidator) String() string       { return v.desc }
+func (v arrayValidator) Validate(i any) error { return v.ValidateContext(nil, i) }
+
+func (v arrayValidator) Kind() validator.Kind { return validator.KindEach }
+func (v arrayValidator) Name() string         { return "array" }
+func (v arrayValidator) Args() []any          { return nil }
+func (v arrayValidator) Children() []validator.Validator {
+	return append([]validator.Validator(nil), v.validators...)
+}
+
+func (v arrayValidator) ValidateContext(c *validator.Context, i any) error {
+	var errs validator.Errors
+	validate := func(index int, value any) bool {
+		err := validator.ValidateContext(c, v.validator, value)
+		return c.Collect(&errs, validator.WrapError(err, indexPath(index), v.rule, value))
+	}
+
+	switch vs := i.(type) {
+	case []string:
+		for i, s := range vs {
+			if !validate(i, s) {
+				break
 			}
+		}
 
+	default:
+		vf := reflect.ValueOf(i)
+		if vf.Kind() == reflect.Ptr {
+			vf = vf.Elem()
+		}
+		switch vf.Kind() {
+		case reflect.Slice, reflect.Array:
 		default:
-			vf := reflect.ValueOf(i)
-			if vf.Kind() == reflect.Ptr {
-				vf = vf.Elem()
-			}
-			switch vf.Kind() {
-			case reflect.Slice, reflect.Array:
-			default:
-				return fmt.Errorf("expect the value is a slice or array, but got %T", i)
-			}
+			return fmt.Errorf("expect the value is a slice or array, but got %T", i)
+		}
 
-			for i, _len := 0, vf.Len(); i < _len; i++ {
-				vf.Index(i).Interface()
-				if err := _validator.Validate(vf.Index(i).Interface()); err != nil {
-					return fmt.Errorf("%dth element is invalid: %v", i, err)
-				}
+		for i, _len := 0, vf.Len(); i < _len; i++ {
+			if !validate(i, vf.Index(i).Interface()) {
+				break
 			}
 		}
+	}
 
-		return nil
-	})
+	return errs.Err()
 }
+
+func indexPath(i int) string { return fmt.Sprintf("[%d]", i) }
@@ -27,4 +27,11 @@ func TestArray(t *testing.T) {
 	expectResultNil(t, "array1", array.Validate([]string{}))
 	expectResultNil(t, "array2", array.Validate([]string{"a", "b"}))
 	unexpectResultNil(t, "array3", array.Validate([]string{"a", ""}))
+
+	var ve *validator.ValidationError
+	if err := array.Validate([]any{"a", "b", ""}); !errors.As(err, &ve) {
+		t.Errorf("expect a ValidationError, but got %v", err)
+	} else if ve.Path != "[2]" || ve.Rule != "bool" || ve.Value != "" {
+		t.Errorf("unexpected ValidationError: path=%s, rule=%s, value=%v", ve.Path, ve.Rule, ve.Value)
+	}
 }
@@ -0,0 +1,222 @@
+// Copyright 2025 xgfone
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validators
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+
+	"github.com/xgfone/go-validation/internal"
+	"github.com/xgfone/go-validation/validator"
+)
+
+var compareOps = map[string]string{
+	"==": "be equal to",
+	"!=": "not be equal to",
+	"<":  "be less than",
+	"<=": "be less than or equal to",
+	">":  "be greater than",
+	">=": "be greater than or equal to",
+}
+
+func checkCompareOp(op string) string {
+	desc, ok := compareOps[op]
+	if !ok {
+		panic(fmt.Errorf("unsupported comparison operator '%s'", op))
+	}
+	return desc
+}
+
+func compareInt(op string, v, n int) bool {
+	switch op {
+	case "==":
+		return v == n
+	case "!=":
+		return v != n
+	case "<":
+		return v < n
+	case "<=":
+		return v <= n
+	case ">":
+		return v > n
+	default:
+		return v >= n
+	}
+}
+
+func compareFloat(op string, v, n float64) bool {
+	switch op {
+	case "==":
+		return v == n
+	case "!=":
+		return v != n
+	case "<":
+		return v < n
+	case "<=":
+		return v <= n
+	case ">":
+		return v > n
+	default:
+		return v >= n
+	}
+}
+
+func compareString(op string, v, s string) bool {
+	switch op {
+	case "==":
+		return v == s
+	case "!=":
+		return v != s
+	case "<":
+		return v < s
+	case "<=":
+		return v <= s
+	case ">":
+		return v > s
+	default:
+		return v >= s
+	}
+}
+
+// Len returns a validator to check whether the length of the value
+// satisfies the comparison "len op n", such as "len >= 3".
+//
+// op must be one of "==", "!=", "<", "<=", ">" and ">=".
+//
+// Support the types as follow:
+//   - String: compare the number of the characters by CountString
+//   - Array, Slice, Map: compare the number of the elements
+//   - Pointer to types above
+//
+// The validator rule is "len op n".
+func Len(op string, n int) validator.Validator {
+	desc := checkCompareOp(op)
+	rule := fmt.Sprintf("len %s %d", op, n)
+	errLength := fmt.Errorf("the length must %s %d", desc, n)
+	return validator.Leaf(validator.NewValidator(rule, func(v any) error {
+		var length int
+		switch t := internal.Indirect(v).(type) {
+		case nil:
+			return errNilPointer
+
+		case string:
+			length = CountString(t)
+
+		default:
+			switch vf := reflect.ValueOf(t); vf.Kind() {
+			case reflect.String:
+				length = CountString(vf.String())
+			case reflect.Array, reflect.Slice, reflect.Map:
+				length = vf.Len()
+			default:
+				return fmt.Errorf("unsupported type '%T'", v)
+			}
+		}
+
+		if !compareInt(op, length, n) {
+			return errLength
+		}
+		return nil
+	}), "len", op, n)
+}
+
+// Value returns a validator to check whether the value satisfies
+// the comparison "value op v", such as "value > 0".
+//
+// op must be one of "==", "!=", "<", "<=", ">" and ">=".
+// And v must be an int, float64, time.Duration, string or bool.
+//
+// Support the types as follow:
+//   - Integer, Float: compare the value if v is an int, float64 or time.Duration
+//   - String: compare the string if v is a string
+//   - Bool: only support "==" and "!=" if v is a bool
+//   - Pointer to types above
+//
+// The validator rule is "value op v".
+func Value(op string, v any) validator.Validator {
+	desc := checkCompareOp(op)
+
+	var s string
+	var number float64
+	switch _v := v.(type) {
+	case int:
+		number = float64(_v)
+		s = strconv.FormatInt(int64(_v), 10)
+
+	case float64:
+		number = _v
+		s = internal.FormatFloat(_v)
+
+	case time.Duration:
+		number = float64(_v)
+		s = _v.String()
+
+	case string:
+		s = strconv.Quote(_v)
+
+	case bool:
+		if op != "==" && op != "!=" {
+			panic(fmt.Errorf("unsupported comparison operator '%s' for bool", op))
+		}
+		s = strconv.FormatBool(_v)
+
+	default:
+		panic(fmt.Errorf("unsupported comparison value type %T", v))
+	}
+
+	rule := fmt.Sprintf("value %s %s", op, s)
+	errValue := fmt.Errorf("the value must %s %s", desc, s)
+	return validator.Leaf(validator.NewValidator(rule, func(i any) error {
+		value := internal.Indirect(i)
+		if value == nil {
+			return errNilPointer
+		}
+
+		var ok bool
+		vf := reflect.ValueOf(value)
+		switch expect := v.(type) {
+		case int, float64, time.Duration:
+			switch vf.Kind() {
+			case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+				ok = compareFloat(op, float64(vf.Int()), number)
+			case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+				ok = compareFloat(op, float64(vf.Uint()), number)
+			case reflect.Float32, reflect.Float64:
+				ok = compareFloat(op, vf.Float(), number)
+			default:
+				return fmt.Errorf("expect a number, but got %T", i)
+			}
+
+		case string:
+			if vf.Kind() != reflect.String {
+				return fmt.Errorf("expect a string, but got %T", i)
+			}
+			ok = compareString(op, vf.String(), expect)
+
+		case bool:
+			if vf.Kind() != reflect.Bool {
+				return fmt.Errorf("expect a bool, but got %T", i)
+			}
+			ok = (vf.Bool() == expect) == (op == "==")
+		}
+
+		if !ok {
+			return errValue
+		}
+		return nil
+	}), "value", op, v)
+}
@@ -0,0 +1,77 @@
+// Copyright 2025 xgfone
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validators
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLen(t *testing.T) {
+	v := Len(">=", 3)
+	if s := v.String(); s != "len >= 3" {
+		t.Errorf("expect rule '%s', but got '%s'", "len >= 3", s)
+	}
+
+	s := "abc"
+	expectResultNil(t, "len1", v.Validate("abc"))
+	expectResultNil(t, "len2", v.Validate(&s))
+	expectResultNil(t, "len3", v.Validate([]int{1, 2, 3}))
+	expectResultNil(t, "len4", v.Validate(map[int]int{1: 1, 2: 2, 3: 3, 4: 4}))
+	unexpectResultNil(t, "len5", v.Validate("ab"))
+	unexpectResultNil(t, "len6", v.Validate([2]int{}))
+	unexpectResultNil(t, "len7", v.Validate(123))
+	unexpectResultNil(t, "len8", v.Validate((*string)(nil)))
+
+	expectResultNil(t, "len9", Len("!=", 0).Validate("a"))
+	unexpectResultNil(t, "len10", Len("<", 2).Validate("ab"))
+}
+
+func TestValue(t *testing.T) {
+	v := Value(">", 0)
+	if s := v.String(); s != "value > 0" {
+		t.Errorf("expect rule '%s', but got '%s'", "value > 0", s)
+	}
+
+	i := 1
+	expectResultNil(t, "value1", v.Validate(1))
+	expectResultNil(t, "value2", v.Validate(&i))
+	expectResultNil(t, "value3", v.Validate(uint8(1)))
+	expectResultNil(t, "value4", v.Validate(0.5))
+	expectResultNil(t, "value5", v.Validate(time.Second))
+	unexpectResultNil(t, "value6", v.Validate(0))
+	unexpectResultNil(t, "value7", v.Validate(-0.5))
+	unexpectResultNil(t, "value8", v.Validate("1"))
+
+	v = Value("!=", "abc")
+	if s := v.String(); s != `value != "abc"` {
+		t.Errorf("expect rule '%s', but got '%s'", `value != "abc"`, s)
+	}
+	expectResultNil(t, "value9", v.Validate("xyz"))
+	unexpectResultNil(t, "value10", v.Validate("abc"))
+	unexpectResultNil(t, "value11", v.Validate(123))
+
+	v = Value("==", true)
+	expectResultNil(t, "value12", v.Validate(true))
+	unexpectResultNil(t, "value13", v.Validate(false))
+	expectResultNil(t, "value14", Value("!=", true).Validate(false))
+
+	v = Value("<", time.Hour)
+	if s := v.String(); s != "value < 1h0m0s" {
+		t.Errorf("expect rule '%s', but got '%s'", "value < 1h0m0s", s)
+	}
+	expectResultNil(t, "value15", v.Validate(time.Minute))
+	unexpectResultNil(t, "value16", v.Validate(2*time.Hour))
+}
@@ -0,0 +1,348 @@
+// Copyright 2025 xgfone
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validators
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/xgfone/go-validation/internal"
+	"github.com/xgfone/go-validation/validator"
+)
+
+// The cross-field validators refer to the sibling fields of the validated
+// value by the parent of the validation context, which is set when validating
+// the fields of a struct. The field name may be a dotted path, such as
+// "Address.City", and the parent may be a struct or a map with string keys.
+
+var errNoParent = errors.New("no parent struct to refer to the field")
+
+// EqField returns a validator to check whether the value is equal to
+// the sibling field.
+//
+// The validator rule is `eqfield("field")`.
+func EqField(field string) validator.Validator {
+	return fieldComparator("eqfield", "==", field)
+}
+
+// NeField returns a validator to check whether the value is not equal to
+// the sibling field.
+//
+// The validator rule is `nefield("field")`.
+func NeField(field string) validator.Validator {
+	return fieldComparator("nefield", "!=", field)
+}
+
+// GtField returns a validator to check whether the value is greater than
+// the sibling field.
+//
+// Support the types as follow:
+//   - Integer, Float: compare the value
+//   - String: compare the string lexicographically
+//   - time.Time: compare the time
+//   - Pointer to types above
+//
+// The validator rule is `gtfield("field")`.
+func GtField(field string) validator.Validator {
+	return fieldComparator("gtfield", ">", field)
+}
+
+// GteField is the same as GtField, but checks whether the value is
+// greater than or equal to the sibling field.
+//
+// The validator rule is `gtefield("field")`.
+func GteField(field string) validator.Validator {
+	return fieldComparator("gtefield", ">=", field)
+}
+
+// LtField is the same as GtField, but checks whether the value is
+// less than the sibling field.
+//
+// The validator rule is `ltfield("field")`.
+func LtField(field string) validator.Validator {
+	return fieldComparator("ltfield", "<", field)
+}
+
+// LteField is the same as GtField, but checks whether the value is
+// less than or equal to the sibling field.
+//
+// The validator rule is `ltefield("field")`.
+func LteField(field string) validator.Validator {
+	return fieldComparator("ltefield", "<=", field)
+}
+
+func fieldComparator(name, op, field string) validator.Validator {
+	desc := checkCompareOp(op)
+	rule := internal.FormatCall(name, field)
+	errField := fmt.Errorf("the value must %s the field %s", desc, field)
+	return validator.Leaf(validator.NewContextValidator(rule, func(c *validator.Context, value any) error {
+		other, err := lookupField(c, field)
+		if err != nil {
+			return err
+		}
+
+		ok, err := compareValues(op, value, other)
+		switch {
+		case err != nil:
+			return err
+		case !ok:
+			return errField
+		default:
+			return nil
+		}
+	}), name, field)
+}
+
+// RequiredIf returns a validator to check whether the value is not ZERO
+// when all the sibling fields are equal to the given values.
+//
+// fieldValues is the pairs of the field name and value, such as
+// RequiredIf("Type", "card"). And the field value is formatted
+// by fmt.Sprint to compare with the given value.
+//
+// The validator rule is `required_if("field1", "value1", ...)`.
+func RequiredIf(fieldValues ...string) validator.Validator {
+	return fieldCondition("required_if", false, true, fieldValues)
+}
+
+// RequiredUnless is the same as RequiredIf, but checks whether the value
+// is not ZERO unless all the sibling fields are equal to the given values.
+//
+// The validator rule is `required_unless("field1", "value1", ...)`.
+func RequiredUnless(fieldValues ...string) validator.Validator {
+	return fieldCondition("required_unless", false, false, fieldValues)
+}
+
+// ExcludedIf is the same as RequiredIf, but checks whether the value
+// is ZERO when all the sibling fields are equal to the given values.
+//
+// The validator rule is `excluded_if("field1", "value1", ...)`.
+func ExcludedIf(fieldValues ...string) validator.Validator {
+	return fieldCondition("excluded_if", true, true, fieldValues)
+}
+
+// ExcludedUnless is the same as RequiredIf, but checks whether the value
+// is ZERO unless all the sibling fields are equal to the given values.
+//
+// The validator rule is `excluded_unless("field1", "value1", ...)`.
+func ExcludedUnless(fieldValues ...string) validator.Validator {
+	return fieldCondition("excluded_unless", true, false, fieldValues)
+}
+
+func fieldCondition(name string, zero, ifmatch bool, fieldValues []string) validator.Validator {
+	if len(fieldValues) == 0 || len(fieldValues)%2 != 0 {
+		panic(fmt.Errorf("%s: the arguments must be the pairs of the field and value", name))
+	}
+
+	var conds []string
+	for i := 0; i < len(fieldValues); i += 2 {
+		conds = append(conds, fmt.Sprintf("%s is %s", fieldValues[i], fieldValues[i+1]))
+	}
+
+	cond := strings.Join(conds, " and ")
+	if !ifmatch {
+		cond = "not " + cond
+	}
+	err := fieldConditionError(zero, cond)
+
+	rule := internal.FormatCall(name, fieldValues...)
+	return validator.Leaf(validator.NewContextValidator(rule, func(c *validator.Context, value any) error {
+		matched := true
+		for i := 0; i < len(fieldValues); i += 2 {
+			other, err := lookupField(c, fieldValues[i])
+			if err != nil {
+				return err
+			}
+
+			if fmt.Sprint(internal.Indirect(other)) != fieldValues[i+1] {
+				matched = false
+				break
+			}
+		}
+
+		if matched == ifmatch && internal.IsZero(value) != zero {
+			return err
+		}
+		return nil
+	}), name, stringsToArgs(fieldValues)...)
+}
+
+// RequiredWith returns a validator to check whether the value is not ZERO
+// when any of the sibling fields is not ZERO.
+//
+// The validator rule is `required_with("field1", ...)`.
+func RequiredWith(fields ...string) validator.Validator {
+	return fieldPresence("required_with", false, true, fields)
+}
+
+// RequiredWithout returns a validator to check whether the value is not ZERO
+// when any of the sibling fields is ZERO.
+//
+// The validator rule is `required_without("field1", ...)`.
+func RequiredWithout(fields ...string) validator.Validator {
+	return fieldPresence("required_without", false, false, fields)
+}
+
+// ExcludedWith returns a validator to check whether the value is ZERO
+// when any of the sibling fields is not ZERO.
+//
+// The validator rule is `excluded_with("field1", ...)`.
+func ExcludedWith(fields ...string) validator.Validator {
+	return fieldPresence("excluded_with", true, true, fields)
+}
+
+// ExcludedWithout returns a validator to check whether the value is ZERO
+// when any of the sibling fields is ZERO.
+//
+// The validator rule is `excluded_without("field1", ...)`.
+func ExcludedWithout(fields ...string) validator.Validator {
+	return fieldPresence("excluded_without", true, false, fields)
+}
+
+func fieldPresence(name string, zero, present bool, fields []string) validator.Validator {
+	if len(fields) == 0 {
+		panic(fmt.Errorf("%s: need at least one field", name))
+	}
+
+	rule := internal.FormatCall(name, fields...)
+	return validator.Leaf(validator.NewContextValidator(rule, func(c *validator.Context, value any) error {
+		if internal.IsZero(value) == zero {
+			return nil
+		}
+
+		for _, field := range fields {
+			other, err := lookupField(c, field)
+			if err != nil {
+				return err
+			}
+
+			if internal.IsZero(other) != present {
+				if present {
+					return fieldConditionError(zero, fmt.Sprintf("%s is present", field))
+				}
+				return fieldConditionError(zero, fmt.Sprintf("%s is absent", field))
+			}
+		}
+		return nil
+	}), name, stringsToArgs(fields)...)
+}
+
+func fieldConditionError(zero bool, cond string) error {
+	if zero {
+		return fmt.Errorf("the value should be empty when %s", cond)
+	}
+	return fmt.Errorf("the value cannot be empty when %s", cond)
+}
+
+// lookupField returns the value of the field from the parent of the context.
+func lookupField(c *validator.Context, field string) (value any, err error) {
+	if c == nil || c.Parent == nil {
+		return nil, errNoParent
+	}
+
+	value = c.Parent
+	for _, name := range strings.Split(field, ".") {
+		vf := reflect.ValueOf(value)
+		for vf.Kind() == reflect.Ptr || vf.Kind() == reflect.Interface {
+			if vf.IsNil() {
+				return nil, fmt.Errorf("the field %s is not found", field)
+			}
+			vf = vf.Elem()
+		}
+
+		switch vf.Kind() {
+		case reflect.Struct:
+			fv := vf.FieldByName(name)
+			if !fv.IsValid() || !fv.CanInterface() {
+				return nil, fmt.Errorf("the field %s is not found", field)
+			}
+			value = fv.Interface()
+
+		case reflect.Map:
+			if vf.Type().Key().Kind() != reflect.String {
+				return nil, fmt.Errorf("the field %s is not found", field)
+			}
+
+			fv := vf.MapIndex(reflect.ValueOf(name).Convert(vf.Type().Key()))
+			if !fv.IsValid() {
+				return nil, fmt.Errorf("the field %s is not found", field)
+			}
+			value = fv.Interface()
+
+		default:
+			return nil, fmt.Errorf("the field %s is not found", field)
+		}
+	}
+
+	return
+}
+
+// compareValues compares the value with other by the operator op.
+func compareValues(op string, value, other any) (ok bool, err error) {
+	value, other = internal.Indirect(value), internal.Indirect(other)
+
+	if t1, ok := value.(time.Time); ok {
+		t2, ok := other.(time.Time)
+		if !ok {
+			return false, fmt.Errorf("cannot compare %T with %T", value, other)
+		}
+		return compareInt(op, compareTime(t1, t2), 0), nil
+	}
+
+	v1, v2 := reflect.ValueOf(value), reflect.ValueOf(other)
+	if f1, ok := toFloat(v1); ok {
+		if f2, ok := toFloat(v2); ok {
+			return compareFloat(op, f1, f2), nil
+		}
+	} else if v1.Kind() == reflect.String && v2.Kind() == reflect.String {
+		return compareString(op, v1.String(), v2.String()), nil
+	}
+
+	switch op {
+	case "==":
+		return reflect.DeepEqual(value, other), nil
+	case "!=":
+		return !reflect.DeepEqual(value, other), nil
+	default:
+		return false, fmt.Errorf("cannot compare %T with %T", value, other)
+	}
+}
+
+func compareTime(t1, t2 time.Time) int {
+	switch {
+	case t1.Before(t2):
+		return -1
+	case t1.After(t2):
+		return 1
+	default:
+		return 0
+	}
+}
+
+func toFloat(v reflect.Value) (float64, bool) {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(v.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), true
+	default:
+		return 0, false
+	}
+}
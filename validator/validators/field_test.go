@@ -0,0 +1,99 @@
+// Copyright 2025 xgfone
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validators
+
+import (
+	"testing"
+	"time"
+
+	"github.com/xgfone/go-validation/validator"
+)
+
+func TestFieldComparator(t *testing.T) {
+	now := time.Now()
+	parent := struct {
+		Password string
+		Min      int
+		Start    time.Time
+		Address  struct{ City string }
+	}{Password: "abc", Min: 10, Start: now}
+	parent.Address.City = "beijing"
+
+	c := new(validator.Context).WithParent(&parent)
+	validate := func(v validator.Validator, value any) error {
+		return validator.ValidateContext(c, v, value)
+	}
+
+	if s := EqField("Password").String(); s != `eqfield("Password")` {
+		t.Errorf(`expect rule 'eqfield("Password")', but got '%s'`, s)
+	}
+
+	expectResultNil(t, "eqfield1", validate(EqField("Password"), "abc"))
+	unexpectResultNil(t, "eqfield2", validate(EqField("Password"), "abd"))
+	unexpectResultNil(t, "eqfield3", validate(EqField("Unknown"), "abc"))
+	expectResultNil(t, "eqfield4", validate(EqField("Address.City"), "beijing"))
+	unexpectResultNil(t, "eqfield5", EqField("Password").Validate("abc"))
+
+	expectResultNil(t, "nefield1", validate(NeField("Password"), "abd"))
+	unexpectResultNil(t, "nefield2", validate(NeField("Password"), "abc"))
+
+	expectResultNil(t, "gtfield1", validate(GtField("Min"), 11))
+	expectResultNil(t, "gtfield2", validate(GtField("Min"), 10.5))
+	unexpectResultNil(t, "gtfield3", validate(GtField("Min"), uint(10)))
+	expectResultNil(t, "gtfield4", validate(GtField("Start"), now.Add(time.Second)))
+	unexpectResultNil(t, "gtfield5", validate(GtField("Start"), now))
+	unexpectResultNil(t, "gtfield6", validate(GtField("Start"), 123))
+
+	expectResultNil(t, "gtefield", validate(GteField("Min"), 10))
+	expectResultNil(t, "ltfield", validate(LtField("Min"), 9))
+	expectResultNil(t, "ltefield1", validate(LteField("Min"), 10))
+	unexpectResultNil(t, "ltefield2", validate(LteField("Min"), 11))
+}
+
+func TestFieldCondition(t *testing.T) {
+	parent := map[string]any{"Type": "card", "Count": 2, "Name": ""}
+	c := new(validator.Context).WithParent(parent)
+	validate := func(v validator.Validator, value any) error {
+		return validator.ValidateContext(c, v, value)
+	}
+
+	if s := RequiredIf("Type", "card").String(); s != `required_if("Type", "card")` {
+		t.Errorf(`expect rule 'required_if("Type", "card")', but got '%s'`, s)
+	}
+
+	expectResultNil(t, "required_if1", validate(RequiredIf("Type", "card"), "123"))
+	unexpectResultNil(t, "required_if2", validate(RequiredIf("Type", "card"), ""))
+	expectResultNil(t, "required_if3", validate(RequiredIf("Type", "cash"), ""))
+	unexpectResultNil(t, "required_if4", validate(RequiredIf("Type", "card", "Count", "2"), 0))
+	expectResultNil(t, "required_if5", validate(RequiredIf("Type", "card", "Count", "3"), 0))
+
+	expectResultNil(t, "required_unless1", validate(RequiredUnless("Type", "card"), ""))
+	unexpectResultNil(t, "required_unless2", validate(RequiredUnless("Type", "cash"), ""))
+
+	expectResultNil(t, "excluded_if1", validate(ExcludedIf("Type", "card"), ""))
+	unexpectResultNil(t, "excluded_if2", validate(ExcludedIf("Type", "card"), "123"))
+	expectResultNil(t, "excluded_unless", validate(ExcludedUnless("Type", "card"), "123"))
+
+	expectResultNil(t, "required_with1", validate(RequiredWith("Name"), ""))
+	unexpectResultNil(t, "required_with2", validate(RequiredWith("Type"), ""))
+	expectResultNil(t, "required_with3", validate(RequiredWith("Type"), "abc"))
+
+	unexpectResultNil(t, "required_without1", validate(RequiredWithout("Name"), ""))
+	expectResultNil(t, "required_without2", validate(RequiredWithout("Type"), ""))
+
+	unexpectResultNil(t, "excluded_with1", validate(ExcludedWith("Type"), "abc"))
+	expectResultNil(t, "excluded_with2", validate(ExcludedWith("Name"), "abc"))
+	unexpectResultNil(t, "excluded_without", validate(ExcludedWithout("Name"), "abc"))
+}
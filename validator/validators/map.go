@@ -17,12 +17,22 @@ package validators
 import (
 	"fmt"
 	"reflect"
+	"sort"
+	"strings"
 
 	"github.com/xgfone/go-validation/validator"
 )
 
 func composeValidators(name string, validators ...validator.Validator) (validator.Validator, string) {
 	validator := validator.And(validators...)
+	if len(validators) > 1 { // Keep the validators as the arguments.
+		descs := make([]string, len(validators))
+		for i, v := range validators {
+			descs[i] = v.String()
+		}
+		return validator, fmt.Sprintf("%s(%s)", name, strings.Join(descs, ", "))
+	}
+
 	desc := validator.String()
 	if desc[0] == '(' {
 		desc = name + desc
@@ -32,6 +42,8 @@ func composeValidators(name string, validators ...validator.Validator) (validato
 	return validator, desc
 }
 
+func keyPath(key any) string { return fmt.Sprintf("[%v]", key) }
+
 // MapK returns a new Validator to use the given validators to check
 // each key of the map.
 //
@@ -40,39 +52,7 @@ func MapK(validators ...validator.Validator) validator.Validator {
 	if len(validators) == 0 {
 		panic("MapKValidator: need at least one validator")
 	}
-
-	_validator, desc := composeValidators("mapk", validators...)
-	return validator.NewValidator(desc, func(i any) error {
-		switch vs := i.(type) {
-		case map[string]string:
-			for key := range vs {
-				if err := _validator.Validate(key); err != nil {
-					return fmt.Errorf("map key '%s' is invalid: %v", key, err)
-				}
-			}
-
-		case map[string]any:
-			for key := range vs {
-				if err := _validator.Validate(key); err != nil {
-					return fmt.Errorf("map key '%s' is invalid: %v", key, err)
-				}
-			}
-
-		default:
-			vf := reflect.ValueOf(i)
-			if vf.Kind() != reflect.Map {
-				return fmt.Errorf("expect the value is a map, but got %T", i)
-			}
-
-			for _, key := range vf.MapKeys() {
-				if err := _validator.Validate(key.Interface()); err != nil {
-					return fmt.Errorf("map key '%v' is invalid: %v", key.Interface(), err)
-				}
-			}
-		}
-
-		return nil
-	})
+	return newMapValidator("mapk", validators, func(k, _ any) any { return k })
 }
 
 // MapV returns a new Validator to use the given validators to check
@@ -83,40 +63,7 @@ func MapV(validators ...validator.Validator) validator.Validator {
 	if len(validators) == 0 {
 		panic("MapVValidator: need at least one validator")
 	}
-
-	_validator, desc := composeValidators("mapv", validators...)
-	return validator.NewValidator(desc, func(i any) error {
-		switch vs := i.(type) {
-		case map[string]string:
-			for _, value := range vs {
-				if err := _validator.Validate(value); err != nil {
-					return fmt.Errorf("map value '%s' is invalid: %v", value, err)
-				}
-			}
-
-		case map[string]any:
-			for _, value := range vs {
-				if err := _validator.Validate(value); err != nil {
-					return fmt.Errorf("map value '%v' is invalid: %v", value, err)
-				}
-			}
-
-		default:
-			vf := reflect.ValueOf(i)
-			if vf.Kind() != reflect.Map {
-				return fmt.Errorf("expect the value is a map, but got %T", i)
-			}
-
-			for iter := vf.MapRange(); iter.Next(); {
-				value := iter.Value().Interface()
-				if err := _validator.Validate(value); err != nil {
-					return fmt.Errorf("map value '%v' is invalid: %v", value, err)
-				}
-			}
-		}
-
-		return nil
-	})
+	return newMapValidator("mapv", validators, func(_, v any) any { return v })
 }
 
 // KV represents a key-value pair.
@@ -135,39 +82,121 @@ func MapKV(validators ...validator.Validator) validator.Validator {
 	if len(validators) == 0 {
 		panic("MapKVValidator: need at least one validator")
 	}
+	return newMapValidator("mapkv", validators, func(k, v any) any { return KV{Key: k, Value: v} })
+}
 
-	_validator, desc := composeValidators("mapkv", validators...)
-	return validator.NewValidator(desc, func(i any) error {
-		switch vs := i.(type) {
-		case map[string]string:
-			for key, value := range vs {
-				if err := _validator.Validate(KV{Key: key, Value: value}); err != nil {
-					return fmt.Errorf("map from key '%v' is invalid: %v", key, err)
-				}
+type mapValidator struct {
+	name       string
+	desc       string
+	rule       string
+	validator  validator.Validator
+	validators []validator.Validator
+	getvalue   func(key, value any) any
+}
+
+func newMapValidator(name string, validators []validator.Validator,
+	getvalue func(key, value any) any) validator.Validator {
+	_validator, desc := composeValidators(name, validators...)
+	return mapValidator{
+		name:       name,
+		desc:       desc,
+		rule:       _validator.String(),
+		validator:  _validator,
+		validators: validators,
+		getvalue:   getvalue,
+	}
+}
+
+func (v mapValidator) String() string       { return v.desc }
+func (v mapValidator) Validate(i any) error { return v.ValidateContext(nil, i) }
+
+func (v mapValidator) Kind() validator.Kind { return validator.KindEach }
+func (v mapValidator) Name() string         { return v.name }
+func (v mapValidator) Args() []any          { return nil }
+func (v mapValidator) Children() []validator.Validator {
+	return append([]validator.Validator(nil), v.validators...)
+}
+
+func (v mapValidator) ValidateContext(c *validator.Context, i any) error {
+	var errs validator.Errors
+	validate := func(key, value any) bool {
+		value = v.getvalue(key, value)
+		err := validator.ValidateContext(c, v.validator, value)
+		return c.Collect(&errs, validator.WrapError(err, keyPath(key), v.rule, value))
+	}
+
+	// Iterate the keys in order when collecting all the errors,
+	// so that the same errors are collected in the same order.
+	if c.CollectAll() {
+		vf := reflect.ValueOf(i)
+		if vf.Kind() != reflect.Map {
+			return fmt.Errorf("expect the value is a map, but got %T", i)
+		}
+
+		for _, key := range sortMapKeys(vf.MapKeys()) {
+			if !validate(key.Interface(), vf.MapIndex(key).Interface()) {
+				break
+			}
+		}
+		return errs.Err()
+	}
+
+	switch vs := i.(type) {
+	case map[string]string:
+		for key, value := range vs {
+			if !validate(key, value) {
+				break
 			}
+		}
 
-		case map[string]any:
-			for key, value := range vs {
-				if err := _validator.Validate(KV{Key: key, Value: value}); err != nil {
-					return fmt.Errorf("map from key '%v' is invalid: %v", key, err)
-				}
+	case map[string]any:
+		for key, value := range vs {
+			if !validate(key, value) {
+				break
 			}
+		}
+
+	default:
+		vf := reflect.ValueOf(i)
+		if vf.Kind() != reflect.Map {
+			return fmt.Errorf("expect the value is a map, but got %T", i)
+		}
 
-		default:
-			vf := reflect.ValueOf(i)
-			if vf.Kind() != reflect.Map {
-				return fmt.Errorf("expect the value is a map, but got %T", i)
+		for iter := vf.MapRange(); iter.Next(); {
+			if !validate(iter.Key().Interface(), iter.Value().Interface()) {
+				break
 			}
+		}
+	}
+
+	return errs.Err()
+}
+
+// sortMapKeys sorts the keys of the map, which are compared by the value
+// for the string, integer, float and bool keys, or by the formatted string.
+func sortMapKeys(keys []reflect.Value) []reflect.Value {
+	sort.SliceStable(keys, func(i, j int) bool {
+		a, b := keys[i], keys[j]
+		if a.Kind() == reflect.Interface && b.Kind() == reflect.Interface {
+			a, b = a.Elem(), b.Elem()
+		}
 
-			for iter := vf.MapRange(); iter.Next(); {
-				key := iter.Key().Interface()
-				value := iter.Value().Interface()
-				if err := _validator.Validate(KV{Key: key, Value: value}); err != nil {
-					return fmt.Errorf("map from key '%v' is invalid: %v", key, err)
-				}
+		if a.Kind() == b.Kind() {
+			switch a.Kind() {
+			case reflect.String:
+				return a.String() < b.String()
+			case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+				return a.Int() < b.Int()
+			case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+				return a.Uint() < b.Uint()
+			case reflect.Float32, reflect.Float64:
+				return a.Float() < b.Float()
+			case reflect.Bool:
+				return !a.Bool() && b.Bool()
 			}
 		}
 
-		return nil
+		return fmt.Sprintf("%T:%v", a.Interface(), a.Interface()) < fmt.Sprintf("%T:%v", b.Interface(), b.Interface())
 	})
+	return keys
 }
@@ -16,6 +16,9 @@ package validators
 
 import (
 	"errors"
+	"fmt"
+	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/xgfone/go-validation/validator"
@@ -62,3 +65,30 @@ func TestMapKV(t *testing.T) {
 	unexpectResultNil(t, "mapkv3", mapk.Validate(map[int]string{9: "a", 10: "b"}))
 	unexpectResultNil(t, "mapkv3", mapk.Validate(map[int]string{1: "a", 9: ""}))
 }
+
+func TestMapValidateAllInOrder(t *testing.T) {
+	maxint9 := func(i any) bool { return i.(int) <= 9 }
+	mapv := MapV(validator.NewValidator("max", validator.BoolValidateFunc(maxint9, errors.New("test"))))
+	value := map[string]int{"e": 10, "d": 10, "c": 10, "b": 10, "a": 10}
+
+	expect := validator.ValidateAll(mapv, value, 3).Error()
+	if !strings.Contains(expect, "[a]") || !strings.Contains(expect, "[c]") || strings.Contains(expect, "[d]") {
+		t.Fatalf("expect the errors of the keys a, b and c, but got '%s'", expect)
+	} else if strings.Index(expect, "[a]") > strings.Index(expect, "[c]") {
+		t.Errorf("expect the errors in the order of the keys, but got '%s'", expect)
+	}
+
+	for i := 0; i < 10; i++ {
+		if s := validator.ValidateAll(mapv, value, 3).Error(); s != expect {
+			t.Errorf("expect the errors '%s', but got '%s'", expect, s)
+		}
+	}
+
+	var keys []any
+	for _, key := range sortMapKeys(reflect.ValueOf(map[any]int{"b": 1, 2: 1, "a": 1, 1: 1}).MapKeys()) {
+		keys = append(keys, key.Interface())
+	}
+	if s := fmt.Sprint(keys); s != "[1 2 a b]" {
+		t.Errorf("expect the keys '[1 2 a b]', but got '%s'", s)
+	}
+}
@@ -37,44 +37,44 @@ import (
 //
 // The validator rule is "mac".
 func Mac() validator.Validator {
-	return validator.NewBoolValidator("mac", func(value string) bool {
+	return validator.Leaf(validator.NewBoolValidator("mac", func(value string) bool {
 		ha, err := net.ParseMAC(value)
 		return err == nil && len(ha) == 6
-	}, errors.New("the string is not a valid mac"))
+	}, errors.New("the string is not a valid mac")), "mac")
 }
 
 // IP returns a new Validator to chech whether the value is a valid IP.
 //
 // The validator rule is "ip".
 func IP() validator.Validator {
-	return validator.NewBoolValidator("ip", func(value string) bool {
+	return validator.Leaf(validator.NewBoolValidator("ip", func(value string) bool {
 		return net.ParseIP(value) != nil
-	}, errors.New("the string is not a valid ip"))
+	}, errors.New("the string is not a valid ip")), "ip")
 }
 
 // Cidr returns a new Validator to chech whether the value is a valid cidr.
 //
 // The validator rule is "cidr".
 func Cidr() validator.Validator {
-	return validator.NewBoolValidator("cidr", func(value string) bool {
+	return validator.Leaf(validator.NewBoolValidator("cidr", func(value string) bool {
 		_, _, err := net.ParseCIDR(value)
 		return err == nil
-	}, errors.New("the string is not a valid cidr"))
+	}, errors.New("the string is not a valid cidr")), "cidr")
 }
 
 // Addr returns a new Validator to chech whether the value is a valid HOST:PORT.
 //
 // The validator rule is "addr".
 func Addr() validator.Validator {
-	return validator.NewBoolValidator("cidr", func(value string) bool {
+	return validator.Leaf(validator.NewBoolValidator("addr", func(value string) bool {
 		host, port, err := net.SplitHostPort(value)
 		return err == nil && host != "" && port != ""
-	}, errors.New("the string is not a valid address"))
+	}, errors.New("the string is not a valid address")), "addr")
 }
 
 func Url() validator.Validator {
-	return validator.NewBoolValidator("url", func(value string) bool {
+	return validator.Leaf(validator.NewBoolValidator("url", func(value string) bool {
 		u, err := url.Parse(value)
 		return err == nil && u.Scheme != "" && u.Host != ""
-	}, errors.New("the string is not a valid url"))
+	}, errors.New("the string is not a valid url")), "url")
 }
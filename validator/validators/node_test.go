@@ -0,0 +1,81 @@
+// Copyright 2025 xgfone
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validators
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/xgfone/go-validation/validator"
+)
+
+func TestNode(t *testing.T) {
+	tests := []struct {
+		validator validator.Validator
+		name      string
+		args      []any
+	}{
+		{Zero(), "zero", nil},
+		{Required(), "required", nil},
+		{Min(1), "min", []any{1.0}},
+		{Ranger(1, 10), "ranger", []any{1.0, 10.0}},
+		{Exp(2, 1, 3), "exp", []any{2, 1, 3}},
+		{Len(">=", 3), "len", []any{">=", 3}},
+		{Value("!=", "a"), "value", []any{"!=", "a"}},
+		{OneOf("a", "b"), "oneof", []any{"a", "b"}},
+		{Regexp("^a$"), "regexp", []any{"^a$"}},
+		{Time("2006"), "time", []any{"2006"}},
+		{TimeIn("2006", time.UTC), "time", []any{"2006", "UTC"}},
+		{Within(time.Hour), "within", []any{time.Hour}},
+		{Addr(), "addr", nil},
+		{EqField("A"), "eqfield", []any{"A"}},
+		{RequiredIf("A", "1"), "required_if", []any{"A", "1"}},
+		{RequiredWith("A", "B"), "required_with", []any{"A", "B"}},
+		{IsType("string"), "istype", []any{"string"}},
+	}
+
+	for _, test := range tests {
+		n, ok := test.validator.(validator.Node)
+		if !ok {
+			t.Errorf("%s: not implement the interface Node", test.name)
+			continue
+		}
+
+		if n.Kind() != validator.KindLeaf {
+			t.Errorf("%s: expect kind leaf, but got %s", test.name, n.Kind())
+		}
+		if n.Name() != test.name {
+			t.Errorf("%s: unexpected name '%s'", test.name, n.Name())
+		}
+		if fmt.Sprint(n.Args()) != fmt.Sprint(test.args) {
+			t.Errorf("%s: expect args %v, but got %v", test.name, test.args, n.Args())
+		}
+		if len(n.Children()) != 0 {
+			t.Errorf("%s: unexpected children", test.name)
+		}
+	}
+
+	for _, v := range []validator.Validator{Array(Min(1), Max(2)), MapK(Min(1), Max(2)), MapV(Min(1), Max(2))} {
+		n := v.(validator.Node)
+		if n.Kind() != validator.KindEach || len(n.Children()) != 2 {
+			t.Errorf("%s: unexpected node %s with %d children", v, n.Kind(), len(n.Children()))
+		}
+	}
+
+	if _, ok := EqField("A").(validator.ContextValidator); !ok {
+		t.Errorf("eqfield: not implement the interface ContextValidator")
+	}
+}
@@ -19,7 +19,6 @@ import (
 	"fmt"
 	"math"
 	"reflect"
-	"strconv"
 
 	"github.com/xgfone/go-validation/internal"
 	"github.com/xgfone/go-validation/validator"
@@ -36,14 +35,14 @@ var errNilPointer = fmt.Errorf("unexpected empty pointer")
 //
 // The validator rule is "min(i)".
 func Min(i float64) validator.Validator {
-	s := strconv.FormatFloat(i, 'f', -1, 64)
+	s := internal.FormatFloat(i)
 	rule := fmt.Sprintf("min(%s)", s)
 
 	errFloat := fmt.Errorf("the float is less than %s", s)
 	errInteger := fmt.Errorf("the integer is less than %s", s)
 	errString := fmt.Errorf("the string length is less than %s", s)
 	errContainer := fmt.Errorf("the length is less than %s", s)
-	return validator.NewValidator(rule, func(v any) error {
+	return validator.Leaf(validator.NewValidator(rule, func(v any) error {
 		switch t := internal.Indirect(v).(type) {
 		case nil:
 			if 0 < i {
@@ -118,7 +117,7 @@ func Min(i float64) validator.Validator {
 		}
 
 		return nil
-	})
+	}), "min", i)
 }
 
 // Max returns a validator to checks the value is greater than i.
@@ -129,14 +128,14 @@ func Min(i float64) validator.Validator {
 //
 // The validator rule is "max(i)".
 func Max(i float64) validator.Validator {
-	s := strconv.FormatFloat(i, 'f', -1, 64)
+	s := internal.FormatFloat(i)
 	rule := fmt.Sprintf("max(%s)", s)
 
 	errFloat := fmt.Errorf("the float is greater than %s", s)
 	errInteger := fmt.Errorf("the integer is greater than %s", s)
 	errString := fmt.Errorf("the string length is greater than %s", s)
 	errContainer := fmt.Errorf("the length is greater than %s", s)
-	return validator.NewValidator(rule, func(v any) error {
+	return validator.Leaf(validator.NewValidator(rule, func(v any) error {
 		switch t := internal.Indirect(v).(type) {
 		case nil:
 			if 0 > i {
@@ -211,7 +210,7 @@ func Max(i float64) validator.Validator {
 		}
 
 		return nil
-	})
+	}), "max", i)
 }
 
 // Ranger returns a validator to checks the value is in range [smallest, biggest],
@@ -225,15 +224,15 @@ func Max(i float64) validator.Validator {
 //
 // Notice: we use ranger instead of range because range is the keyword in Go.
 func Ranger(smallest, biggest float64) validator.Validator {
-	left := strconv.FormatFloat(smallest, 'f', -1, 64)
-	right := strconv.FormatFloat(biggest, 'f', -1, 64)
+	left := internal.FormatFloat(smallest)
+	right := internal.FormatFloat(biggest)
 	rule := fmt.Sprintf("ranger(%s, %s)", left, right)
 
 	errFloat := fmt.Errorf("the float is not in range [%s, %s]", left, right)
 	errInteger := fmt.Errorf("the integer is not in range [%s, %s]", left, right)
 	errString := fmt.Errorf("the string length is not in range [%s, %s]", left, right)
 	errContainer := fmt.Errorf("the length is not in range [%s, %s]", left, right)
-	return validator.NewValidator(rule, func(v any) error {
+	return validator.Leaf(validator.NewValidator(rule, func(v any) error {
 		switch t := internal.Indirect(v).(type) {
 		case nil:
 			if !inRange(0, smallest, biggest) {
@@ -309,7 +308,7 @@ func Ranger(smallest, biggest float64) validator.Validator {
 		}
 
 		return nil
-	})
+	}), "ranger", smallest, biggest)
 }
 
 func inRange(v, smallest, biggest float64) bool {
@@ -350,7 +349,7 @@ func Exp(base, startExp, endExp int) validator.Validator {
 	errInteger := fmt.Errorf("the integer is not in range [%s]", buf.String())
 
 	rule := fmt.Sprintf("exp(%d,%d,%d)", base, startExp, endExp)
-	return validator.NewValidator(rule, func(i any) error {
+	return validator.Leaf(validator.NewValidator(rule, func(i any) error {
 		switch v := internal.Indirect(i).(type) {
 		case int:
 			if !inRangeInt64(int64(v), values) {
@@ -398,7 +397,7 @@ func Exp(base, startExp, endExp int) validator.Validator {
 			return fmt.Errorf("unsupported type '%T'", i)
 		}
 		return nil
-	})
+	}), "exp", base, startExp, endExp)
 }
 
 func inRangeInt64(v int64, vs []int64) bool {
@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"regexp"
 
+	"github.com/xgfone/go-validation/internal"
 	"github.com/xgfone/go-validation/validator"
 )
 
@@ -31,10 +32,10 @@ func Regexp(rule string) validator.Validator {
 	}
 
 	re := regexp.MustCompile(rule)
-	_rule := fmt.Sprintf(`regexp("%s")`, rule)
-	return validator.NewBoolValidator(_rule, func(value string) bool {
+	_rule := internal.FormatCall("regexp", rule)
+	return validator.Leaf(validator.NewBoolValidator(_rule, func(value string) bool {
 		return re.MatchString(value)
-	}, fmt.Errorf("invalid string for the regexp: %s", rule))
+	}, fmt.Errorf("invalid string for the regexp: %s", rule)), "regexp", rule)
 }
 
 // RegexpPOSIX is the same as Regexp, but use regexp.MustCompilePOSIX
@@ -47,8 +48,8 @@ func RegexpPOSIX(rule string) validator.Validator {
 	}
 
 	re := regexp.MustCompilePOSIX(rule)
-	_rule := fmt.Sprintf(`posixregexp("%s")`, rule)
-	return validator.NewBoolValidator(_rule, func(value string) bool {
+	_rule := internal.FormatCall("posixregexp", rule)
+	return validator.Leaf(validator.NewBoolValidator(_rule, func(value string) bool {
 		return re.MatchString(value)
-	}, fmt.Errorf("invalid string for the posix regexp: %s", rule))
+	}, fmt.Errorf("invalid string for the posix regexp: %s", rule)), "posixregexp", rule)
 }
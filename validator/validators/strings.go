@@ -38,22 +38,30 @@ func OneOf(values ...string) validator.Validator {
 //
 // The validator rule is "name(values...)".
 func OneOfWithName(name string, values ...string) validator.Validator {
-	return internal.NewOneOf(name, values...)
+	return validator.Leaf(internal.NewOneOf(name, values...), name, stringsToArgs(values)...)
 }
 
 // IsNumber returns a new validator to check whether the string value is a number,
 // such as an integer or float.
 func IsNumber() validator.Validator {
-	return validator.NewBoolValidator("isnumber", func(value string) bool {
+	return validator.Leaf(validator.NewBoolValidator("isnumber", func(value string) bool {
 		_, err := strconv.ParseFloat(value, 64)
 		return err == nil
-	}, errors.New("the string is not a number"))
+	}, errors.New("the string is not a number")), "isnumber")
 }
 
 // IsInteger returns a new validator to check whether the string value is an integer.
 func IsInteger() validator.Validator {
-	return validator.NewBoolValidator("isinteger", func(value string) bool {
+	return validator.Leaf(validator.NewBoolValidator("isinteger", func(value string) bool {
 		_, err := strconv.ParseInt(value, 10, 64)
 		return err == nil
-	}, errors.New("the string is not an integer"))
+	}, errors.New("the string is not an integer")), "isinteger")
+}
+
+func stringsToArgs(ss []string) []any {
+	args := make([]any, len(ss))
+	for i, s := range ss {
+		args[i] = s
+	}
+	return args
 }
@@ -16,8 +16,10 @@ package validators
 
 import (
 	"fmt"
+	"strconv"
 	"time"
 
+	"github.com/xgfone/go-validation/internal"
 	"github.com/xgfone/go-validation/validator"
 )
 
@@ -26,11 +28,11 @@ import (
 //
 // The validator rule is "time(format)".
 func Time(format string) validator.Validator {
-	rule := fmt.Sprintf(`time("%s")`, format)
-	return validator.NewBoolValidator(rule, func(value string) bool {
+	rule := internal.FormatCall("time", format)
+	return validator.Leaf(validator.NewBoolValidator(rule, func(value string) bool {
 		_, err := time.Parse(format, value)
 		return err == nil
-	}, fmt.Errorf("invalid time for the format '%s'", format))
+	}, fmt.Errorf("invalid time for the format '%s'", format)), "time", format)
 }
 
 // Duration returns a new validator to check whether the string value is
@@ -38,8 +40,44 @@ func Time(format string) validator.Validator {
 //
 // The validator rule is "duration".
 func Duration() validator.Validator {
-	return validator.NewBoolValidator("duration", func(value string) bool {
+	return validator.Leaf(validator.NewBoolValidator("duration", func(value string) bool {
 		_, err := time.ParseDuration(value)
 		return err == nil
-	}, fmt.Errorf("invalid duration"))
+	}, fmt.Errorf("invalid duration")), "duration")
+}
+
+// TimeIn is the same as Time, but parses the string value
+// in the given location by time.ParseInLocation.
+//
+// The validator rule is "time(format, loc=location)".
+func TimeIn(format string, loc *time.Location) validator.Validator {
+	rule := fmt.Sprintf("time(%s, loc=%s)", strconv.Quote(format), strconv.Quote(loc.String()))
+	return validator.Leaf(validator.NewBoolValidator(rule, func(value string) bool {
+		_, err := time.ParseInLocation(format, value, loc)
+		return err == nil
+	}, fmt.Errorf("invalid time for the format '%s' in the location '%s'", format, loc)), "time", format, loc.String())
+}
+
+// Within returns a new validator to check whether the time.Time value
+// is within the duration d before or after now.
+//
+// The validator rule is "within(d)", such as "within(24h0m0s)".
+func Within(d time.Duration) validator.Validator {
+	if d < 0 {
+		d = -d
+	}
+
+	rule := fmt.Sprintf("within(%s)", d)
+	errOutside := fmt.Errorf("the time must be within %s from now", d)
+	return validator.Leaf(validator.NewValidator(rule, func(i any) error {
+		t, ok := internal.Indirect(i).(time.Time)
+		if !ok {
+			return fmt.Errorf("expect a time.Time, but got %T", i)
+		}
+
+		if diff := time.Since(t); diff > d || diff < -d {
+			return errOutside
+		}
+		return nil
+	}), "within", d)
 }
@@ -0,0 +1,47 @@
+// Copyright 2025 xgfone
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validators
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTimeIn(t *testing.T) {
+	v := TimeIn("2006-01-02 15:04", time.UTC)
+	if s := v.String(); s != `time("2006-01-02 15:04", loc="UTC")` {
+		t.Errorf("unexpected rule '%s'", s)
+	}
+
+	expectResultNil(t, "time1", v.Validate("2025-01-02 03:04"))
+	unexpectResultNil(t, "time2", v.Validate("2025-01-02"))
+}
+
+func TestWithin(t *testing.T) {
+	v := Within(time.Hour)
+	if s := v.String(); s != "within(1h0m0s)" {
+		t.Errorf("unexpected rule '%s'", s)
+	}
+
+	now := time.Now()
+	expectResultNil(t, "within1", v.Validate(now))
+	expectResultNil(t, "within2", v.Validate(now.Add(-30*time.Minute)))
+	expectResultNil(t, "within3", v.Validate(now.Add(30*time.Minute)))
+	unexpectResultNil(t, "within4", v.Validate(now.Add(-2*time.Hour)))
+	unexpectResultNil(t, "within5", v.Validate(now.Add(2*time.Hour)))
+	unexpectResultNil(t, "within6", v.Validate("2025-01-02"))
+	unexpectResultNil(t, "within7", v.Validate((*time.Time)(nil)))
+	expectResultNil(t, "within8", v.Validate(&now))
+}
@@ -0,0 +1,107 @@
+// Copyright 2025 xgfone
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validators
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/xgfone/go-validation/internal"
+	"github.com/xgfone/go-validation/validator"
+)
+
+var (
+	timeType     = reflect.TypeOf(time.Time{})
+	durationType = reflect.TypeOf(time.Duration(0))
+)
+
+// typeMatchers is the matchers of the types supported by IsType.
+var typeMatchers = map[string]func(reflect.Value) bool{
+	"nil":      func(v reflect.Value) bool { return !v.IsValid() },
+	"time":     func(v reflect.Value) bool { return v.IsValid() && v.Type() == timeType },
+	"duration": func(v reflect.Value) bool { return v.IsValid() && v.Type() == durationType },
+	"integer":  func(v reflect.Value) bool { return isIntKind(v.Kind()) || isUintKind(v.Kind()) },
+	"float":    func(v reflect.Value) bool { return isFloatKind(v.Kind()) },
+	"list":     func(v reflect.Value) bool { return v.Kind() == reflect.Slice || v.Kind() == reflect.Array },
+	"number": func(v reflect.Value) bool {
+		return isIntKind(v.Kind()) || isUintKind(v.Kind()) || isFloatKind(v.Kind())
+	},
+}
+
+func init() {
+	for _, kind := range []reflect.Kind{
+		reflect.Bool, reflect.String, reflect.Slice, reflect.Array, reflect.Map, reflect.Struct,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64,
+	} {
+		kind := kind
+		typeMatchers[kind.String()] = func(v reflect.Value) bool { return v.Kind() == kind }
+	}
+}
+
+func isIntKind(k reflect.Kind) bool   { return k >= reflect.Int && k <= reflect.Int64 }
+func isUintKind(k reflect.Kind) bool  { return k >= reflect.Uint && k <= reflect.Uintptr }
+func isFloatKind(k reflect.Kind) bool { return k == reflect.Float32 || k == reflect.Float64 }
+
+// IsType returns a new Validator to check whether the type of the value,
+// which is dereferenced if it is a pointer, is one of the given types.
+//
+// The supported types are as follow:
+//
+//	bool, string, slice, array, map, struct
+//	int, int8, int16, int32, int64
+//	uint, uint8, uint16, uint32, uint64
+//	float32, float64
+//	integer: any signed or unsigned integer
+//	float: float32 or float64
+//	number: integer or float
+//	list: slice or array
+//	time: time.Time
+//	duration: time.Duration
+//	nil: nil or a nil pointer
+//
+// The kind types, such as "int" and "string", also match the named types,
+// such as time.Duration which is also "int64" and "integer".
+//
+// The validator rule is "istype(types...)", such as `istype("string")`.
+func IsType(types ...string) validator.Validator {
+	if len(types) == 0 {
+		panic("istype: no types")
+	}
+
+	matchers := make([]func(reflect.Value) bool, len(types))
+	for i, t := range types {
+		matcher, ok := typeMatchers[t]
+		if !ok {
+			panic(fmt.Errorf("istype: unsupported type '%s'", t))
+		}
+		matchers[i] = matcher
+	}
+
+	rule := internal.FormatCall("istype", types...)
+	err := fmt.Errorf("the value must be of the type %s", strings.Join(types, " or "))
+	return validator.Leaf(validator.NewValidator(rule, func(i any) error {
+		v := reflect.ValueOf(internal.Indirect(i))
+		for _, match := range matchers {
+			if match(v) {
+				return nil
+			}
+		}
+		return err
+	}), "istype", stringsToArgs(types)...)
+}
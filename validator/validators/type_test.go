@@ -0,0 +1,51 @@
+// Copyright 2025 xgfone
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validators
+
+import (
+	"testing"
+	"time"
+)
+
+func TestIsType(t *testing.T) {
+	v := IsType("string", "integer")
+	if s := v.String(); s != `istype("string", "integer")` {
+		t.Errorf("unexpected rule '%s'", s)
+	}
+
+	s := "abc"
+	expectResultNil(t, "istype1", v.Validate("abc"))
+	expectResultNil(t, "istype2", v.Validate(&s))
+	expectResultNil(t, "istype3", v.Validate(uint8(1)))
+	expectResultNil(t, "istype4", v.Validate(time.Second))
+	unexpectResultNil(t, "istype5", v.Validate(1.5))
+	unexpectResultNil(t, "istype6", v.Validate(nil))
+
+	expectResultNil(t, "istype7", IsType("number").Validate(1.5))
+	expectResultNil(t, "istype8", IsType("time").Validate(time.Now()))
+	expectResultNil(t, "istype9", IsType("duration").Validate(time.Second))
+	unexpectResultNil(t, "istype10", IsType("duration").Validate(int64(1)))
+	expectResultNil(t, "istype11", IsType("list").Validate([2]int{}))
+	expectResultNil(t, "istype12", IsType("nil").Validate((*string)(nil)))
+	expectResultNil(t, "istype13", IsType("int64").Validate(time.Second))
+	unexpectResultNil(t, "istype14", IsType("map", "struct").Validate([]int{}))
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("expect a panic, but got nil")
+		}
+	}()
+	IsType("unknown")
+}
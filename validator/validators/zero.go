@@ -16,8 +16,8 @@ package validators
 
 import (
 	"errors"
-	"reflect"
 
+	"github.com/xgfone/go-validation/internal"
 	"github.com/xgfone/go-validation/validator"
 )
 
@@ -64,20 +64,10 @@ func Required() validator.Validator {
 }
 
 func zeroValidator(name string, zero bool, err error) validator.Validator {
-	return validator.NewValidator(name, func(i any) error {
-		if iszero(i) == zero {
+	return validator.Leaf(validator.NewValidator(name, func(i any) error {
+		if internal.IsZero(i) == zero {
 			return nil
 		}
 		return err
-	})
-}
-
-func iszero(v any) bool {
-	if i, ok := v.(interface{ IsZero() bool }); ok && i.IsZero() {
-		return true
-	}
-	if reflect.ValueOf(v).IsZero() {
-		return true
-	}
-	return false
+	}), name)
 }
@@ -0,0 +1,213 @@
+// Copyright 2025 xgfone
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/xgfone/go-validation/validator"
+)
+
+// maxVarValidators is the maximum number of the validators cached by LRU
+// in a varValidator for the different variable values.
+const maxVarValidators = 64
+
+// ValidateWithVars is equal to DefaultBuilder.ValidateWithVars(v, rule, vars).
+func ValidateWithVars(v any, rule string, vars validator.Vars) error {
+	return DefaultBuilder.ValidateWithVars(v, rule, vars)
+}
+
+// ValidateWithVars is the same as Validate, but resolves the runtime
+// variables referred to by the rule from vars, such as
+//
+//	b.ValidateWithVars(v, "min(1) && max($quota)", validator.VarMap{"quota": 100})
+//
+// The validator built from the rule is cached and reused for the different
+// vars, and only the parts referring to the variables, such as "max($quota)",
+// are built when validating the value with the resolved variables.
+//
+// The variable value of the integer, float, string or bool kind is converted
+// to int, float64, string or bool, and the slice or array is converted to
+// a list, such as []string{"a", "b"} => ["a", "b"].
+//
+// If failing to build the rule or the parts referring to the variables,
+// the returned error is a *RuleError like TryValidate.
+func (b *Builder) ValidateWithVars(v any, rule string, vars validator.Vars) (err error) {
+	if rule == "" {
+		return nil
+	}
+
+	_validator, err := b.BuildValidator(rule)
+	if err != nil {
+		return NewRuleError("", rule, err)
+	}
+	return validate(&validator.Context{Vars: vars}, _validator, v)
+}
+
+// refersToVars reports whether the argument or operand node refers to
+// the runtime variables directly, not including the sub-rules.
+func refersToVars(node ruleNode) bool {
+	switch n := node.(type) {
+	case *varNode:
+		return true
+
+	case *kwargNode:
+		return refersToVars(n.value)
+
+	case *listNode:
+		for _, elem := range n.elems {
+			if refersToVars(elem) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// varValidator is the validator of the rule node referring to the runtime
+// variables, which is built when validating the value by resolving
+// the variables from the validation context.
+type varValidator struct {
+	builder *Builder
+	node    ruleNode
+	rule    string
+	cache   *validatorCache // The key is the resolved rule.
+}
+
+func (b *Builder) newVarValidator(node ruleNode) validator.Validator {
+	// Re-parse the rule of the node so that the positions of the errors
+	// are relative to the rule of the validator, not the original one.
+	rule := node.String()
+	if _node, err := parseRule(rule); err == nil {
+		node = _node
+	}
+	return &varValidator{builder: b, node: node, rule: rule, cache: newValidatorCache(maxVarValidators)}
+}
+
+func (v *varValidator) String() string       { return v.rule }
+func (v *varValidator) Validate(i any) error { return v.ValidateContext(nil, i) }
+
+func (v *varValidator) ValidateContext(c *validator.Context, i any) error {
+	node, err := resolveVars(c, v.node)
+	if err != nil {
+		return err
+	}
+
+	_validator, err := v.build(node)
+	if err != nil {
+		return err
+	}
+	return validator.ValidateContext(c, _validator, i)
+}
+
+func (v *varValidator) build(node ruleNode) (validator.Validator, error) {
+	rule := node.String()
+	v.cache.Sync(v.builder.getVersion())
+	_validator, gen, ok := v.cache.Get(rule)
+	if ok {
+		return _validator, nil
+	}
+
+	c := NewContext()
+	if err := v.builder.buildRoot(c, v.rule, node); err != nil {
+		return nil, NewRuleError("", v.rule, err)
+	}
+	return v.cache.Add(gen, rule, v.builder.validator(c)), nil
+}
+
+// resolveVars returns a copy of the node, the variables of which are
+// replaced with the literals of their values from the context.
+func resolveVars(c *validator.Context, node ruleNode) (_ ruleNode, err error) {
+	switch n := node.(type) {
+	case *varNode:
+		value, ok := c.Var(n.name)
+		if !ok {
+			return nil, fmt.Errorf("the variable $%s is not provided", n.name)
+		}
+		return &literalNode{pos: n.pos, end: n.end, value: normalizeVar(value)}, nil
+
+	case *binaryNode:
+		nn := *n
+		if nn.x, err = resolveVars(c, n.x); err == nil {
+			nn.y, err = resolveVars(c, n.y)
+		}
+		return &nn, err
+
+	case *notNode:
+		nn := *n
+		nn.x, err = resolveVars(c, n.x)
+		return &nn, err
+
+	case *kwargNode:
+		nn := *n
+		nn.value, err = resolveVars(c, n.value)
+		return &nn, err
+
+	case *callNode:
+		nn := *n
+		nn.args, err = resolveNodes(c, n.args)
+		return &nn, err
+
+	case *listNode:
+		nn := *n
+		nn.elems, err = resolveNodes(c, n.elems)
+		return &nn, err
+
+	default:
+		return node, nil
+	}
+}
+
+func resolveNodes(c *validator.Context, nodes []ruleNode) (_ []ruleNode, err error) {
+	resolved := make([]ruleNode, len(nodes))
+	for i, node := range nodes {
+		if resolved[i], err = resolveVars(c, node); err != nil {
+			return
+		}
+	}
+	return resolved, nil
+}
+
+// normalizeVar converts the variable value to the type of the literal.
+func normalizeVar(value any) any {
+	switch value.(type) {
+	case nil, int, float64, string, bool, time.Duration:
+		return value
+	}
+
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return int(v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return int(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		return v.Float()
+	case reflect.String:
+		return v.String()
+	case reflect.Bool:
+		return v.Bool()
+	case reflect.Slice, reflect.Array:
+		list := make([]any, v.Len())
+		for i := range list {
+			list[i] = normalizeVar(v.Index(i).Interface())
+		}
+		return list
+	default:
+		return value
+	}
+}
@@ -0,0 +1,174 @@
+// Copyright 2025 xgfone
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/xgfone/go-validation/validator"
+)
+
+func TestValidateWithVars(t *testing.T) {
+	b := NewBuilder()
+	RegisterDefaultsForBuilder(b)
+
+	const rule = "min(1) && max($quota)"
+	basic := validator.VarMap{"quota": 10}
+	pro := validator.VarMap{"quota": uint16(100)}
+
+	if err := b.ValidateWithVars(50, rule, basic); err == nil {
+		t.Errorf("expect an error, but got nil")
+	}
+	if err := b.ValidateWithVars(50, rule, pro); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := b.ValidateWithVars(5, rule, basic); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if stats := b.CacheStats(); stats.Size != 1 || stats.Hits != 2 {
+		t.Errorf("expect the validator is cached and reused, but got %+v", stats)
+	}
+
+	v, _ := b.BuildValidator(rule)
+	if s := v.String(); s != "(min(1) && max($quota))" {
+		t.Errorf("unexpected rule '%s'", s)
+	}
+
+	tests := []struct {
+		value any
+		rule  string
+		vars  validator.Vars
+		valid bool
+	}{
+		{"a", `oneof($allowed)`, validator.VarMap{"allowed": []string{"a", "b"}}, true},
+		{"c", `oneof($allowed)`, validator.VarMap{"allowed": []string{"a", "b"}}, false},
+		{"c", `oneof([$first, "c"])`, validator.VarMap{"first": "a"}, true},
+		{5, "value < $limit", validator.VarMap{"limit": 5.5}, true},
+		{6, "value < $limit", validator.VarMap{"limit": 5.5}, false},
+		{"abc", "len <= $plan.max", validator.VarMap{"plan.max": 3}, true},
+		{[]int{1, 20}, "array(max($max))", validator.VarMap{"max": 10}, false},
+		{"abc", `time($layout, loc=$loc) || max($max)`, validator.VarFunc(func(name string) (any, bool) {
+			return map[string]any{"layout": "2006", "loc": "UTC", "max": 3}[name], name != "unknown"
+		}), true},
+	}
+
+	for _, test := range tests {
+		err := b.ValidateWithVars(test.value, test.rule, test.vars)
+		if test.valid && err != nil {
+			t.Errorf("%s: unexpected error: %v", test.rule, err)
+		} else if !test.valid && err == nil {
+			t.Errorf("%s: expect an error, but got nil", test.rule)
+		}
+	}
+
+	err := b.ValidateWithVars(1, rule, validator.VarMap{})
+	if err == nil || err.Error() != "the variable $quota is not provided" {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	err = b.Validate(1, rule)
+	if err == nil || err.Error() != "the variable $quota is not provided" {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	err = b.ValidateWithVars(1, rule, validator.VarMap{"quota": "abc"})
+	if !errors.Is(err, ErrInvalidRule) {
+		t.Errorf("expect a rule error, but got %v", err)
+	}
+}
+
+func TestValidateStructWithVars(t *testing.T) {
+	var s struct {
+		Name string `validate:"oneof($names)"`
+		Age  int    `validate:"min(1) && max($maxAge)"`
+	}
+
+	s.Name, s.Age = "xgfone", 30
+	vars := validator.VarMap{"names": []string{"xgfone"}, "maxAge": 20}
+	if err := ValidateWithVars(s, "structure", vars); err == nil {
+		t.Errorf("expect an error, but got nil")
+	}
+
+	vars["maxAge"] = 100
+	if err := ValidateWithVars(s, "structure", vars); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestVarRuleError(t *testing.T) {
+	tests := []struct {
+		rule   string
+		expect string
+	}{
+		{"max($ quota)", "1:5: expected variable name after '$'"},
+		{"max($1)", "1:5: expected variable name after '$'"},
+		{"max($a.)", "1:8: expected name, but got ')'"},
+		{"$quota", "1:1: unexpected variable $quota"},
+		{"nonexistent($a)", "1:1: unsupported function: nonexistent"},
+	}
+
+	for _, test := range tests {
+		if _, err := DefaultBuilder.BuildValidator(test.rule); err == nil {
+			t.Errorf("%q: expect an error, but got nil", test.rule)
+		} else if s := err.Error(); s != test.expect {
+			t.Errorf("%q: expect error '%s', but got '%s'", test.rule, test.expect, s)
+		}
+	}
+
+	vars := validator.VarMap{"n": 1}
+	if err := ValidateWithVars(1, "min(1", vars); !errors.Is(err, ErrInvalidRule) {
+		t.Errorf("expect the error ErrInvalidRule, but got %v", err)
+	}
+	if err := ValidateWithVars(1, "max($n, 2)", vars); err == nil {
+		t.Errorf("expect an error, but got nil")
+	} else if re, ok := err.(*RuleError); !ok {
+		t.Errorf("expect a RuleError, but got %T: %v", err, err)
+	} else if re.Rule != "max($n, 2)" {
+		t.Errorf("expect the rule '%s', but got '%s'", "max($n, 2)", re.Rule)
+	}
+}
+
+func TestVarValidatorCache(t *testing.T) {
+	v, err := DefaultBuilder.BuildValidator("max($n)")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	vv, ok := v.(*varValidator)
+	if !ok {
+		t.Fatalf("expect a varValidator, but got %T", v)
+	}
+
+	for i := 0; i < 200; i++ {
+		c := &validator.Context{Vars: validator.VarMap{"n": i + 10}}
+		if err := validator.ValidateContext(c, v, 1); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		c = &validator.Context{Vars: validator.VarMap{"n": 5}}
+		if err := validator.ValidateContext(c, v, 1); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	stats := vv.cache.Stats()
+	if stats.Size != maxVarValidators {
+		t.Errorf("expect %d cached validators, but got %d", maxVarValidators, stats.Size)
+	}
+	if stats.Hits != 199 {
+		t.Errorf("expect %d cache hits, but got %d", 199, stats.Hits)
+	}
+}